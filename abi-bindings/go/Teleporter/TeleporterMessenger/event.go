@@ -4,9 +4,12 @@
 package teleportermessenger
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -22,6 +25,7 @@ const (
 	MessageExecuted
 	RelayerRewardsRedeemed
 	ReceiptReceived
+	BlockchainIDInitialized
 
 	sendCrossChainMessageStr    = "SendCrossChainMessage"
 	receiveCrossChainMessageStr = "ReceiveCrossChainMessage"
@@ -30,6 +34,7 @@ const (
 	messageExecutedStr          = "MessageExecuted"
 	relayerRewardsRedeemedStr   = "RelayerRewardsRedeemed"
 	receiptReceivedStr          = "ReceiptReceived"
+	blockchainIDInitializedStr  = "BlockchainIDInitialized"
 	unknownStr                  = "Unknown"
 )
 
@@ -50,6 +55,8 @@ func (e Event) String() string {
 		return relayerRewardsRedeemedStr
 	case ReceiptReceived:
 		return receiptReceivedStr
+	case BlockchainIDInitialized:
+		return blockchainIDInitializedStr
 	default:
 		return unknownStr
 	}
@@ -72,6 +79,8 @@ func ToEvent(e string) (Event, error) {
 		return RelayerRewardsRedeemed, nil
 	case strings.ToLower(receiptReceivedStr):
 		return ReceiptReceived, nil
+	case strings.ToLower(blockchainIDInitializedStr):
+		return BlockchainIDInitialized, nil
 	default:
 		return Unknown, fmt.Errorf("unknown event %s", e)
 	}
@@ -99,6 +108,8 @@ func FilterTeleporterEvents(topics []common.Hash, data []byte, event string) (in
 		out = new(TeleporterMessengerRelayerRewardsRedeemed)
 	case ReceiptReceived:
 		out = new(TeleporterMessengerReceiptReceived)
+	case BlockchainIDInitialized:
+		out = new(TeleporterMessengerBlockchainIDInitialized)
 	default:
 		return nil, fmt.Errorf("unknown event %s", e.String())
 	}
@@ -107,3 +118,145 @@ func FilterTeleporterEvents(topics []common.Hash, data []byte, event string) (in
 	}
 	return out, nil
 }
+
+// ErrUnknownEvent is returned when parsing a log that is not a Teleporter event
+var ErrUnknownEvent = errors.New("unknown event")
+
+// TeleporterEvent is a Teleporter log event parsed by ParseTeleporterLog. The concrete type is one of
+// the *<Name>Event types below, each embedding the corresponding generated event struct, so that the
+// fields of the event, such as MessageID, can be accessed directly.
+type TeleporterEvent interface {
+	// Event returns the kind of the event
+	Event() Event
+	// GetMessageID returns the ID of the message the event is about, or ids.Empty for
+	// RelayerRewardsRedeemed and BlockchainIDInitialized, which are not about a message
+	GetMessageID() ids.ID
+	// Log returns the log the event was parsed from
+	Log() types.Log
+}
+
+type (
+	SendCrossChainMessageEvent struct {
+		TeleporterMessengerSendCrossChainMessage
+	}
+	ReceiveCrossChainMessageEvent struct {
+		TeleporterMessengerReceiveCrossChainMessage
+	}
+	AddFeeAmountEvent struct {
+		TeleporterMessengerAddFeeAmount
+	}
+	MessageExecutionFailedEvent struct {
+		TeleporterMessengerMessageExecutionFailed
+	}
+	MessageExecutedEvent struct {
+		TeleporterMessengerMessageExecuted
+	}
+	RelayerRewardsRedeemedEvent struct {
+		TeleporterMessengerRelayerRewardsRedeemed
+	}
+	ReceiptReceivedEvent struct {
+		TeleporterMessengerReceiptReceived
+	}
+	BlockchainIDInitializedEvent struct {
+		TeleporterMessengerBlockchainIDInitialized
+	}
+)
+
+// ParseTeleporterLog parses a log emitted by the TeleporterMessenger contract into the Teleporter event
+// identified by the log's first topic. Returns ErrUnknownEvent if the log is not a Teleporter event.
+func ParseTeleporterLog(log types.Log) (TeleporterEvent, error) {
+	if len(log.Topics) == 0 {
+		return nil, fmt.Errorf("%w: log has no topics", ErrUnknownEvent)
+	}
+	teleporterABI, err := TeleporterMessengerMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get abi: %v", err)
+	}
+	abiEvent, err := teleporterABI.EventByID(log.Topics[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w with ID %s", ErrUnknownEvent, log.Topics[0].Hex())
+	}
+	parsed, err := FilterTeleporterEvents(log.Topics, log.Data, abiEvent.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e := parsed.(type) {
+	case *TeleporterMessengerSendCrossChainMessage:
+		e.Raw = log
+		return &SendCrossChainMessageEvent{*e}, nil
+	case *TeleporterMessengerReceiveCrossChainMessage:
+		e.Raw = log
+		return &ReceiveCrossChainMessageEvent{*e}, nil
+	case *TeleporterMessengerAddFeeAmount:
+		e.Raw = log
+		return &AddFeeAmountEvent{*e}, nil
+	case *TeleporterMessengerMessageExecutionFailed:
+		e.Raw = log
+		return &MessageExecutionFailedEvent{*e}, nil
+	case *TeleporterMessengerMessageExecuted:
+		e.Raw = log
+		return &MessageExecutedEvent{*e}, nil
+	case *TeleporterMessengerRelayerRewardsRedeemed:
+		e.Raw = log
+		return &RelayerRewardsRedeemedEvent{*e}, nil
+	case *TeleporterMessengerReceiptReceived:
+		e.Raw = log
+		return &ReceiptReceivedEvent{*e}, nil
+	case *TeleporterMessengerBlockchainIDInitialized:
+		e.Raw = log
+		return &BlockchainIDInitializedEvent{*e}, nil
+	default:
+		return nil, fmt.Errorf("%w %s", ErrUnknownEvent, abiEvent.Name)
+	}
+}
+
+func (SendCrossChainMessageEvent) Event() Event    { return SendCrossChainMessage }
+func (ReceiveCrossChainMessageEvent) Event() Event { return ReceiveCrossChainMessage }
+func (AddFeeAmountEvent) Event() Event             { return AddFeeAmount }
+func (MessageExecutionFailedEvent) Event() Event   { return MessageExecutionFailed }
+func (MessageExecutedEvent) Event() Event          { return MessageExecuted }
+func (RelayerRewardsRedeemedEvent) Event() Event   { return RelayerRewardsRedeemed }
+func (ReceiptReceivedEvent) Event() Event          { return ReceiptReceived }
+func (BlockchainIDInitializedEvent) Event() Event  { return BlockchainIDInitialized }
+
+func (e SendCrossChainMessageEvent) GetMessageID() ids.ID {
+	return e.MessageID
+}
+
+func (e ReceiveCrossChainMessageEvent) GetMessageID() ids.ID {
+	return e.MessageID
+}
+
+func (e AddFeeAmountEvent) GetMessageID() ids.ID {
+	return e.MessageID
+}
+
+func (e MessageExecutionFailedEvent) GetMessageID() ids.ID {
+	return e.MessageID
+}
+
+func (e MessageExecutedEvent) GetMessageID() ids.ID {
+	return e.MessageID
+}
+
+func (RelayerRewardsRedeemedEvent) GetMessageID() ids.ID {
+	return ids.Empty
+}
+
+func (e ReceiptReceivedEvent) GetMessageID() ids.ID {
+	return e.MessageID
+}
+
+func (BlockchainIDInitializedEvent) GetMessageID() ids.ID {
+	return ids.Empty
+}
+
+func (e SendCrossChainMessageEvent) Log() types.Log    { return e.Raw }
+func (e ReceiveCrossChainMessageEvent) Log() types.Log { return e.Raw }
+func (e AddFeeAmountEvent) Log() types.Log             { return e.Raw }
+func (e MessageExecutionFailedEvent) Log() types.Log   { return e.Raw }
+func (e MessageExecutedEvent) Log() types.Log          { return e.Raw }
+func (e RelayerRewardsRedeemedEvent) Log() types.Log   { return e.Raw }
+func (e ReceiptReceivedEvent) Log() types.Log          { return e.Raw }
+func (e BlockchainIDInitializedEvent) Log() types.Log  { return e.Raw }
@@ -4,10 +4,12 @@
 package teleportermessenger
 
 import (
+	"errors"
 	"math/big"
 	"testing"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
@@ -25,6 +27,8 @@ func TestEventString(t *testing.T) {
 			{MessageExecutionFailed, messageExecutionFailedStr},
 			{MessageExecuted, messageExecutedStr},
 			{RelayerRewardsRedeemed, relayerRewardsRedeemedStr},
+			{ReceiptReceived, receiptReceivedStr},
+			{BlockchainIDInitialized, blockchainIDInitializedStr},
 		}
 	)
 
@@ -49,6 +53,8 @@ func TestToEvent(t *testing.T) {
 			{messageExecutionFailedStr, MessageExecutionFailed, false},
 			{messageExecutedStr, MessageExecuted, false},
 			{relayerRewardsRedeemedStr, RelayerRewardsRedeemed, false},
+			{receiptReceivedStr, ReceiptReceived, false},
+			{blockchainIDInitializedStr, BlockchainIDInitialized, false},
 		}
 	)
 
@@ -143,3 +149,104 @@ func TestFilterTeleporterEvents(t *testing.T) {
 		})
 	}
 }
+
+func TestParseTeleporterLog(t *testing.T) {
+	mockBlockchainID := ids.ID{1, 2, 3, 4}
+	mockMessageID := ids.ID{9, 10, 11, 12}
+	message := createTestTeleporterMessage(big.NewInt(8))
+	feeInfo := TeleporterFeeInfo{
+		FeeTokenAddress: common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567"),
+		Amount:          big.NewInt(1),
+	}
+	address := common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+
+	teleporterABI, err := TeleporterMessengerMetaData.GetAbi()
+	require.NoError(t, err)
+
+	var (
+		tests = []struct {
+			event     Event
+			args      []interface{}
+			messageID ids.ID
+		}{
+			{
+				event:     SendCrossChainMessage,
+				args:      []interface{}{mockMessageID, mockBlockchainID, message, feeInfo},
+				messageID: mockMessageID,
+			},
+			{
+				event:     ReceiveCrossChainMessage,
+				args:      []interface{}{mockMessageID, mockBlockchainID, address, address, message},
+				messageID: mockMessageID,
+			},
+			{
+				event:     AddFeeAmount,
+				args:      []interface{}{mockMessageID, feeInfo},
+				messageID: mockMessageID,
+			},
+			{
+				event:     MessageExecutionFailed,
+				args:      []interface{}{mockMessageID, mockBlockchainID, message},
+				messageID: mockMessageID,
+			},
+			{
+				event:     MessageExecuted,
+				args:      []interface{}{mockMessageID, mockBlockchainID},
+				messageID: mockMessageID,
+			},
+			{
+				event:     RelayerRewardsRedeemed,
+				args:      []interface{}{address, address, big.NewInt(1)},
+				messageID: ids.Empty,
+			},
+			{
+				event:     ReceiptReceived,
+				args:      []interface{}{mockMessageID, mockBlockchainID, address, feeInfo},
+				messageID: mockMessageID,
+			},
+			{
+				event:     BlockchainIDInitialized,
+				args:      []interface{}{mockBlockchainID},
+				messageID: ids.Empty,
+			},
+		}
+	)
+
+	for _, test := range tests {
+		t.Run(test.event.String(), func(t *testing.T) {
+			topics, data, err := teleporterABI.PackEvent(test.event.String(), test.args...)
+			require.NoError(t, err)
+			log := types.Log{Topics: topics, Data: data, BlockNumber: 10}
+
+			parsed, err := ParseTeleporterLog(log)
+			require.NoError(t, err)
+			require.Equal(t, test.event, parsed.Event())
+			require.Equal(t, test.messageID, parsed.GetMessageID())
+			require.Equal(t, log, parsed.Log())
+		})
+	}
+
+	// The concrete type exposes the fields of the generated event struct
+	topics, data, err := teleporterABI.PackEvent(BlockchainIDInitialized.String(), mockBlockchainID)
+	require.NoError(t, err)
+	parsed, err := ParseTeleporterLog(types.Log{Topics: topics, Data: data})
+	require.NoError(t, err)
+	initialized, ok := parsed.(*BlockchainIDInitializedEvent)
+	require.True(t, ok)
+	require.Equal(t, mockBlockchainID, ids.ID(initialized.BlockchainID))
+
+	topics, data, err = teleporterABI.PackEvent(MessageExecuted.String(), mockMessageID, mockBlockchainID)
+	require.NoError(t, err)
+	parsed, err = ParseTeleporterLog(types.Log{Topics: topics, Data: data})
+	require.NoError(t, err)
+	executed, ok := parsed.(*MessageExecutedEvent)
+	require.True(t, ok)
+	require.Equal(t, mockMessageID, ids.ID(executed.MessageID))
+	require.Equal(t, mockMessageID, executed.GetMessageID())
+
+	// Logs that are not Teleporter events are rejected
+	_, err = ParseTeleporterLog(types.Log{})
+	require.True(t, errors.Is(err, ErrUnknownEvent))
+	_, err = ParseTeleporterLog(types.Log{Topics: []common.Hash{common.HexToHash("0x01")}})
+	require.True(t, errors.Is(err, ErrUnknownEvent))
+}
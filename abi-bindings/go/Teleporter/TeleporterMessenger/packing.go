@@ -70,32 +70,64 @@ func UnpackTeleporterMessage(messageBytes []byte) (*TeleporterMessage, error) {
 	return &teleporterMessage.TeleporterMessage, nil
 }
 
+// PackSendCrossChainMessage packs a TeleporterMessageInput to form a call to the sendCrossChainMessage function
 func PackSendCrossChainMessage(input TeleporterMessageInput) ([]byte, error) {
-	abi, err := TeleporterMessengerMetaData.GetAbi()
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get abi")
-	}
+	return packMethod("sendCrossChainMessage", input)
+}
 
-	return abi.Pack("sendCrossChainMessage", input)
+// PackSendCrossChainMessageOutput packs the ID of the sent message as the output of the sendCrossChainMessage function
+func PackSendCrossChainMessageOutput(messageID ids.ID) ([]byte, error) {
+	return packOutput("sendCrossChainMessage", messageID)
 }
 
+// UnpackSendCrossChainMessageResult unpacks the ID of the sent message returned by the sendCrossChainMessage function
+func UnpackSendCrossChainMessageResult(result []byte) (ids.ID, error) {
+	return unpackIDResult("sendCrossChainMessage", result)
+}
+
+// PackRetryMessageExecution packs a TeleporterMessage to form a call to the retryMessageExecution function
 func PackRetryMessageExecution(sourceBlockchainID ids.ID, message TeleporterMessage) ([]byte, error) {
-	abi, err := TeleporterMessengerMetaData.GetAbi()
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get abi")
-	}
+	return packMethod("retryMessageExecution", sourceBlockchainID, message)
+}
+
+// PackRetrySendCrossChainMessage packs a TeleporterMessage to form a call to the retrySendCrossChainMessage function
+func PackRetrySendCrossChainMessage(message TeleporterMessage) ([]byte, error) {
+	return packMethod("retrySendCrossChainMessage", message)
+}
+
+// PackAddFeeAmount packs the inputs to form a call to the addFeeAmount function
+func PackAddFeeAmount(messageID ids.ID, feeTokenAddress common.Address, additionalFeeAmount *big.Int) ([]byte, error) {
+	return packMethod("addFeeAmount", messageID, feeTokenAddress, additionalFeeAmount)
+}
+
+// PackRedeemRelayerRewards packs the fee token address to form a call to the redeemRelayerRewards function
+func PackRedeemRelayerRewards(feeTokenAddress common.Address) ([]byte, error) {
+	return packMethod("redeemRelayerRewards", feeTokenAddress)
+}
+
+// PackSendSpecifiedReceipts packs the inputs to form a call to the sendSpecifiedReceipts function
+func PackSendSpecifiedReceipts(
+	sourceBlockchainID ids.ID,
+	messageIDs [][32]byte,
+	feeInfo TeleporterFeeInfo,
+	allowedRelayerAddresses []common.Address,
+) ([]byte, error) {
+	return packMethod("sendSpecifiedReceipts", sourceBlockchainID, messageIDs, feeInfo, allowedRelayerAddresses)
+}
 
-	return abi.Pack("retryMessageExecution", sourceBlockchainID, message)
+// PackSendSpecifiedReceiptsOutput packs the ID of the sent message as the output of the sendSpecifiedReceipts function
+func PackSendSpecifiedReceiptsOutput(messageID ids.ID) ([]byte, error) {
+	return packOutput("sendSpecifiedReceipts", messageID)
+}
+
+// UnpackSendSpecifiedReceiptsResult unpacks the ID of the sent message returned by the sendSpecifiedReceipts function
+func UnpackSendSpecifiedReceiptsResult(result []byte) (ids.ID, error) {
+	return unpackIDResult("sendSpecifiedReceipts", result)
 }
 
 // PackReceiveCrossChainMessage packs a ReceiveCrossChainMessageInput to form a call to the receiveCrossChainMessage function
 func PackReceiveCrossChainMessage(messageIndex uint32, relayerRewardAddress common.Address) ([]byte, error) {
-	abi, err := TeleporterMessengerMetaData.GetAbi()
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get abi")
-	}
-
-	return abi.Pack("receiveCrossChainMessage", messageIndex, relayerRewardAddress)
+	return packMethod("receiveCrossChainMessage", messageIndex, relayerRewardAddress)
 }
 
 // PackCalculateMessageID packs input to form a call to the calculateMessageID function
@@ -103,51 +135,332 @@ func PackCalculateMessageID(
 	sourceBlockchainID [32]byte,
 	destinationBlockchainID [32]byte,
 	nonce *big.Int) ([]byte, error) {
+	return packMethod("calculateMessageID", sourceBlockchainID, destinationBlockchainID, nonce)
+}
+
+func PackCalculateMessageIDOutput(messageID [32]byte) ([]byte, error) {
+	return packOutput("calculateMessageID", messageID)
+}
+
+// UnpackCalculateMessageIDResult unpacks the message ID returned by the calculateMessageID function
+func UnpackCalculateMessageIDResult(result []byte) (ids.ID, error) {
+	return unpackIDResult("calculateMessageID", result)
+}
+
+// PackMessageReceived packs a MessageReceivedInput to form a call to the messageReceived function
+func PackMessageReceived(messageID [32]byte) ([]byte, error) {
+	return packMethod("messageReceived", messageID)
+}
+
+// UnpackMessageReceivedResult attempts to unpack result bytes to a bool indicating whether the message was received
+func UnpackMessageReceivedResult(result []byte) (bool, error) {
+	out, err := unpackResult("messageReceived", result)
+	if err != nil {
+		return false, err
+	}
+	return *abi.ConvertType(out[0], new(bool)).(*bool), nil
+}
+
+func PackMessageReceivedOutput(success bool) ([]byte, error) {
+	return packOutput("messageReceived", success)
+}
+
+// PackWarpMessenger packs a call to the WARP_MESSENGER function
+func PackWarpMessenger() ([]byte, error) {
+	return packMethod("WARP_MESSENGER")
+}
+
+// PackWarpMessengerOutput packs the Warp precompile address as the output of the WARP_MESSENGER function
+func PackWarpMessengerOutput(warpMessenger common.Address) ([]byte, error) {
+	return packOutput("WARP_MESSENGER", warpMessenger)
+}
+
+// UnpackWarpMessengerResult unpacks the Warp precompile address returned by the WARP_MESSENGER function
+func UnpackWarpMessengerResult(result []byte) (common.Address, error) {
+	return unpackAddressResult("WARP_MESSENGER", result)
+}
+
+// PackBlockchainID packs a call to the blockchainID function
+func PackBlockchainID() ([]byte, error) {
+	return packMethod("blockchainID")
+}
+
+// PackBlockchainIDOutput packs the blockchain ID as the output of the blockchainID function
+func PackBlockchainIDOutput(blockchainID ids.ID) ([]byte, error) {
+	return packOutput("blockchainID", blockchainID)
+}
+
+// UnpackBlockchainIDResult unpacks the blockchain ID returned by the blockchainID function. The ID is
+// empty if it has not been initialized.
+func UnpackBlockchainIDResult(result []byte) (ids.ID, error) {
+	return unpackIDResult("blockchainID", result)
+}
+
+// PackInitializeBlockchainID packs a call to the initializeBlockchainID function
+func PackInitializeBlockchainID() ([]byte, error) {
+	return packMethod("initializeBlockchainID")
+}
+
+// PackInitializeBlockchainIDOutput packs the blockchain ID as the output of the initializeBlockchainID function
+func PackInitializeBlockchainIDOutput(blockchainID ids.ID) ([]byte, error) {
+	return packOutput("initializeBlockchainID", blockchainID)
+}
+
+// UnpackInitializeBlockchainIDResult unpacks the blockchain ID returned by the initializeBlockchainID function
+func UnpackInitializeBlockchainIDResult(result []byte) (ids.ID, error) {
+	return unpackIDResult("initializeBlockchainID", result)
+}
+
+// PackMessageNonce packs a call to the messageNonce function
+func PackMessageNonce() ([]byte, error) {
+	return packMethod("messageNonce")
+}
+
+// PackMessageNonceOutput packs the nonce as the output of the messageNonce function
+func PackMessageNonceOutput(nonce *big.Int) ([]byte, error) {
+	return packOutput("messageNonce", nonce)
+}
+
+// UnpackMessageNonceResult unpacks the nonce returned by the messageNonce function
+func UnpackMessageNonceResult(result []byte) (*big.Int, error) {
+	return unpackUint256Result("messageNonce", result)
+}
+
+// PackGetNextMessageID packs a call to the getNextMessageID function
+func PackGetNextMessageID(destinationBlockchainID ids.ID) ([]byte, error) {
+	return packMethod("getNextMessageID", destinationBlockchainID)
+}
+
+// PackGetNextMessageIDOutput packs the message ID as the output of the getNextMessageID function
+func PackGetNextMessageIDOutput(messageID ids.ID) ([]byte, error) {
+	return packOutput("getNextMessageID", messageID)
+}
+
+// UnpackGetNextMessageIDResult unpacks the message ID returned by the getNextMessageID function
+func UnpackGetNextMessageIDResult(result []byte) (ids.ID, error) {
+	return unpackIDResult("getNextMessageID", result)
+}
+
+// PackGetMessageHash packs a call to the getMessageHash function
+func PackGetMessageHash(messageID ids.ID) ([]byte, error) {
+	return packMethod("getMessageHash", messageID)
+}
+
+// PackGetMessageHashOutput packs the message hash as the output of the getMessageHash function
+func PackGetMessageHashOutput(messageHash common.Hash) ([]byte, error) {
+	return packOutput("getMessageHash", messageHash)
+}
+
+// UnpackGetMessageHashResult unpacks the message hash returned by the getMessageHash function
+func UnpackGetMessageHashResult(result []byte) (common.Hash, error) {
+	return unpackHashResult("getMessageHash", result)
+}
+
+// PackGetFeeInfo packs a call to the getFeeInfo function
+func PackGetFeeInfo(messageID ids.ID) ([]byte, error) {
+	return packMethod("getFeeInfo", messageID)
+}
+
+// PackGetFeeInfoOutput packs the fee info as the output of the getFeeInfo function
+func PackGetFeeInfoOutput(feeInfo TeleporterFeeInfo) ([]byte, error) {
+	return packOutput("getFeeInfo", feeInfo.FeeTokenAddress, feeInfo.Amount)
+}
+
+// UnpackGetFeeInfoResult unpacks the fee token address and amount returned by the getFeeInfo function
+func UnpackGetFeeInfoResult(result []byte) (TeleporterFeeInfo, error) {
+	out, err := unpackResult("getFeeInfo", result)
+	if err != nil {
+		return TeleporterFeeInfo{}, err
+	}
+	return TeleporterFeeInfo{
+		FeeTokenAddress: *abi.ConvertType(out[0], new(common.Address)).(*common.Address),
+		Amount:          *abi.ConvertType(out[1], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+// PackSentMessageInfo packs a call to the sentMessageInfo function
+func PackSentMessageInfo(messageID ids.ID) ([]byte, error) {
+	return packMethod("sentMessageInfo", messageID)
+}
+
+// PackSentMessageInfoOutput packs the message hash and fee info as the output of the sentMessageInfo function
+func PackSentMessageInfoOutput(messageHash common.Hash, feeInfo TeleporterFeeInfo) ([]byte, error) {
+	return packOutput("sentMessageInfo", messageHash, feeInfo)
+}
+
+// UnpackSentMessageInfoResult unpacks the message hash and fee info returned by the sentMessageInfo function
+func UnpackSentMessageInfoResult(result []byte) (common.Hash, TeleporterFeeInfo, error) {
+	out, err := unpackResult("sentMessageInfo", result)
+	if err != nil {
+		return common.Hash{}, TeleporterFeeInfo{}, err
+	}
+	messageHash := *abi.ConvertType(out[0], new(common.Hash)).(*common.Hash)
+	feeInfo := *abi.ConvertType(out[1], new(TeleporterFeeInfo)).(*TeleporterFeeInfo)
+	return messageHash, feeInfo, nil
+}
+
+// PackGetRelayerRewardAddress packs a call to the getRelayerRewardAddress function
+func PackGetRelayerRewardAddress(messageID ids.ID) ([]byte, error) {
+	return packMethod("getRelayerRewardAddress", messageID)
+}
+
+// PackGetRelayerRewardAddressOutput packs the reward address as the output of the getRelayerRewardAddress function
+func PackGetRelayerRewardAddressOutput(relayerRewardAddress common.Address) ([]byte, error) {
+	return packOutput("getRelayerRewardAddress", relayerRewardAddress)
+}
+
+// UnpackGetRelayerRewardAddressResult unpacks the reward address returned by the getRelayerRewardAddress function
+func UnpackGetRelayerRewardAddressResult(result []byte) (common.Address, error) {
+	return unpackAddressResult("getRelayerRewardAddress", result)
+}
+
+// PackCheckRelayerRewardAmount packs a call to the checkRelayerRewardAmount function
+func PackCheckRelayerRewardAmount(relayer common.Address, feeTokenAddress common.Address) ([]byte, error) {
+	return packMethod("checkRelayerRewardAmount", relayer, feeTokenAddress)
+}
+
+// PackCheckRelayerRewardAmountOutput packs the reward amount as the output of the checkRelayerRewardAmount function
+func PackCheckRelayerRewardAmountOutput(amount *big.Int) ([]byte, error) {
+	return packOutput("checkRelayerRewardAmount", amount)
+}
+
+// UnpackCheckRelayerRewardAmountResult unpacks the reward amount returned by the checkRelayerRewardAmount function
+func UnpackCheckRelayerRewardAmountResult(result []byte) (*big.Int, error) {
+	return unpackUint256Result("checkRelayerRewardAmount", result)
+}
+
+// PackReceivedFailedMessageHashes packs a call to the receivedFailedMessageHashes function
+func PackReceivedFailedMessageHashes(messageID ids.ID) ([]byte, error) {
+	return packMethod("receivedFailedMessageHashes", messageID)
+}
+
+// PackReceivedFailedMessageHashesOutput packs the message hash as the output of the receivedFailedMessageHashes
+// function
+func PackReceivedFailedMessageHashesOutput(messageHash common.Hash) ([]byte, error) {
+	return packOutput("receivedFailedMessageHashes", messageHash)
+}
+
+// UnpackReceivedFailedMessageHashesResult unpacks the message hash returned by the receivedFailedMessageHashes
+// function. The hash is empty unless the execution of the message failed and has not been successfully retried.
+func UnpackReceivedFailedMessageHashesResult(result []byte) (common.Hash, error) {
+	return unpackHashResult("receivedFailedMessageHashes", result)
+}
+
+// PackGetReceiptQueueSize packs a call to the getReceiptQueueSize function
+func PackGetReceiptQueueSize(sourceBlockchainID ids.ID) ([]byte, error) {
+	return packMethod("getReceiptQueueSize", sourceBlockchainID)
+}
+
+// PackGetReceiptQueueSizeOutput packs the queue size as the output of the getReceiptQueueSize function
+func PackGetReceiptQueueSizeOutput(size *big.Int) ([]byte, error) {
+	return packOutput("getReceiptQueueSize", size)
+}
+
+// UnpackGetReceiptQueueSizeResult unpacks the queue size returned by the getReceiptQueueSize function
+func UnpackGetReceiptQueueSizeResult(result []byte) (*big.Int, error) {
+	return unpackUint256Result("getReceiptQueueSize", result)
+}
+
+// PackGetReceiptAtIndex packs a call to the getReceiptAtIndex function
+func PackGetReceiptAtIndex(sourceBlockchainID ids.ID, index *big.Int) ([]byte, error) {
+	return packMethod("getReceiptAtIndex", sourceBlockchainID, index)
+}
+
+// PackGetReceiptAtIndexOutput packs the receipt as the output of the getReceiptAtIndex function
+func PackGetReceiptAtIndexOutput(receipt TeleporterMessageReceipt) ([]byte, error) {
+	return packOutput("getReceiptAtIndex", receipt)
+}
+
+// UnpackGetReceiptAtIndexResult unpacks the receipt returned by the getReceiptAtIndex function
+func UnpackGetReceiptAtIndexResult(result []byte) (TeleporterMessageReceipt, error) {
+	out, err := unpackResult("getReceiptAtIndex", result)
+	if err != nil {
+		return TeleporterMessageReceipt{}, err
+	}
+	return *abi.ConvertType(out[0], new(TeleporterMessageReceipt)).(*TeleporterMessageReceipt), nil
+}
+
+// PackReceiptQueues packs a call to the receiptQueues function
+func PackReceiptQueues(sourceBlockchainID ids.ID) ([]byte, error) {
+	return packMethod("receiptQueues", sourceBlockchainID)
+}
+
+// PackReceiptQueuesOutput packs the queue bounds as the output of the receiptQueues function
+func PackReceiptQueuesOutput(first *big.Int, last *big.Int) ([]byte, error) {
+	return packOutput("receiptQueues", first, last)
+}
+
+// UnpackReceiptQueuesResult unpacks the first and last indices of the queue returned by the receiptQueues function
+func UnpackReceiptQueuesResult(result []byte) (*big.Int, *big.Int, error) {
+	out, err := unpackResult("receiptQueues", result)
+	if err != nil {
+		return nil, nil, err
+	}
+	first := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	last := *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	return first, last, nil
+}
+
+// packMethod packs the inputs to form a call to the given method
+func packMethod(method string, args ...interface{}) ([]byte, error) {
 	abi, err := TeleporterMessengerMetaData.GetAbi()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get abi")
 	}
 
-	return abi.Pack("calculateMessageID", sourceBlockchainID, destinationBlockchainID, nonce)
+	return abi.Pack(method, args...)
 }
 
-func PackCalculateMessageIDOutput(messageID [32]byte) ([]byte, error) {
+// packOutput packs the outputs of the given method, as returned by a call to the method
+func packOutput(method string, args ...interface{}) ([]byte, error) {
 	abi, err := TeleporterMessengerMetaData.GetAbi()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get abi")
 	}
 
-	return abi.PackOutput("calculateMessageID", messageID)
+	return abi.PackOutput(method, args...)
 }
 
-// PackMessageReceived packs a MessageReceivedInput to form a call to the messageReceived function
-func PackMessageReceived(messageID [32]byte) ([]byte, error) {
+// unpackResult unpacks the result of a call to the given method into its outputs
+func unpackResult(method string, result []byte) ([]interface{}, error) {
 	abi, err := TeleporterMessengerMetaData.GetAbi()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get abi")
 	}
-	return abi.Pack("messageReceived", messageID)
+
+	return abi.Unpack(method, result)
 }
 
-// UnpackMessageReceivedResult attempts to unpack result bytes to a bool indicating whether the message was received
-func UnpackMessageReceivedResult(result []byte) (bool, error) {
-	abi, err := TeleporterMessengerMetaData.GetAbi()
+func unpackIDResult(method string, result []byte) (ids.ID, error) {
+	out, err := unpackResult(method, result)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to get abi")
+		return ids.Empty, err
 	}
+	return *abi.ConvertType(out[0], new(ids.ID)).(*ids.ID), nil
+}
 
-	var success bool
-	err = abi.UnpackIntoInterface(&success, "messageReceived", result)
-	return success, err
+func unpackHashResult(method string, result []byte) (common.Hash, error) {
+	out, err := unpackResult(method, result)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return *abi.ConvertType(out[0], new(common.Hash)).(*common.Hash), nil
 }
 
-func PackMessageReceivedOutput(success bool) ([]byte, error) {
-	abi, err := TeleporterMessengerMetaData.GetAbi()
+func unpackAddressResult(method string, result []byte) (common.Address, error) {
+	out, err := unpackResult(method, result)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get abi")
+		return common.Address{}, err
 	}
+	return *abi.ConvertType(out[0], new(common.Address)).(*common.Address), nil
+}
 
-	return abi.PackOutput("messageReceived", success)
+func unpackUint256Result(method string, result []byte) (*big.Int, error) {
+	out, err := unpackResult(method, result)
+	if err != nil {
+		return nil, err
+	}
+	return *abi.ConvertType(out[0], new(*big.Int)).(**big.Int), nil
 }
 
 // UnpackEvent unpacks the event data and topics into the provided interface
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
@@ -143,3 +144,287 @@ func TestUnpackEvent(t *testing.T) {
 		})
 	}
 }
+
+// requireSameType asserts that the ABI types have the same encoding and field names
+func requireSameType(t *testing.T, expected abi.Type, actual abi.Type) {
+	require.Equal(t, expected.String(), actual.String())
+	require.Equal(t, expected.T, actual.T)
+	require.Equal(t, expected.TupleRawNames, actual.TupleRawNames)
+	require.Equal(t, len(expected.TupleElems), len(actual.TupleElems))
+	for i := range expected.TupleElems {
+		requireSameType(t, *expected.TupleElems[i], *actual.TupleElems[i])
+	}
+	require.Equal(t, expected.Elem == nil, actual.Elem == nil)
+	if expected.Elem != nil {
+		requireSameType(t, *expected.Elem, *actual.Elem)
+	}
+}
+
+func TestTeleporterMessageTypeMatchesABI(t *testing.T) {
+	teleporterABI, err := TeleporterMessengerMetaData.GetAbi()
+	require.NoError(t, err)
+
+	// sendCrossChainMessage takes a TeleporterMessageInput, so the TeleporterMessage it sends is
+	// checked against the event it emits instead.
+	var (
+		tests = []struct {
+			name     string
+			expected abi.Type
+		}{
+			{
+				name:     "retryMessageExecution",
+				expected: teleporterABI.Methods["retryMessageExecution"].Inputs[1].Type,
+			},
+			{
+				name:     "retrySendCrossChainMessage",
+				expected: teleporterABI.Methods["retrySendCrossChainMessage"].Inputs[0].Type,
+			},
+			{
+				name:     "SendCrossChainMessage",
+				expected: teleporterABI.Events["SendCrossChainMessage"].Inputs[2].Type,
+			},
+		}
+	)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requireSameType(t, test.expected, teleporterMessageType)
+		})
+	}
+}
+
+func TestPackUnpackResults(t *testing.T) {
+	messageID := ids.ID{1, 2, 3}
+	messageHash := common.HexToHash("0x0123")
+	address := common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+	feeInfo := TeleporterFeeInfo{
+		FeeTokenAddress: address,
+		Amount:          big.NewInt(5),
+	}
+	receipt := TeleporterMessageReceipt{
+		ReceivedMessageNonce: big.NewInt(6),
+		RelayerRewardAddress: address,
+	}
+
+	var (
+		tests = []struct {
+			name     string
+			pack     func() ([]byte, error)
+			unpack   func([]byte) (interface{}, error)
+			expected interface{}
+		}{
+			{
+				name:     "sendCrossChainMessage",
+				pack:     func() ([]byte, error) { return PackSendCrossChainMessageOutput(messageID) },
+				unpack:   func(b []byte) (interface{}, error) { return UnpackSendCrossChainMessageResult(b) },
+				expected: messageID,
+			},
+			{
+				name:     "sendSpecifiedReceipts",
+				pack:     func() ([]byte, error) { return PackSendSpecifiedReceiptsOutput(messageID) },
+				unpack:   func(b []byte) (interface{}, error) { return UnpackSendSpecifiedReceiptsResult(b) },
+				expected: messageID,
+			},
+			{
+				name:     "calculateMessageID",
+				pack:     func() ([]byte, error) { return PackCalculateMessageIDOutput(messageID) },
+				unpack:   func(b []byte) (interface{}, error) { return UnpackCalculateMessageIDResult(b) },
+				expected: messageID,
+			},
+			{
+				name:     "messageReceived",
+				pack:     func() ([]byte, error) { return PackMessageReceivedOutput(true) },
+				unpack:   func(b []byte) (interface{}, error) { return UnpackMessageReceivedResult(b) },
+				expected: true,
+			},
+			{
+				name:     "WARP_MESSENGER",
+				pack:     func() ([]byte, error) { return PackWarpMessengerOutput(address) },
+				unpack:   func(b []byte) (interface{}, error) { return UnpackWarpMessengerResult(b) },
+				expected: address,
+			},
+			{
+				name:     "blockchainID",
+				pack:     func() ([]byte, error) { return PackBlockchainIDOutput(messageID) },
+				unpack:   func(b []byte) (interface{}, error) { return UnpackBlockchainIDResult(b) },
+				expected: messageID,
+			},
+			{
+				name:     "initializeBlockchainID",
+				pack:     func() ([]byte, error) { return PackInitializeBlockchainIDOutput(messageID) },
+				unpack:   func(b []byte) (interface{}, error) { return UnpackInitializeBlockchainIDResult(b) },
+				expected: messageID,
+			},
+			{
+				name:     "messageNonce",
+				pack:     func() ([]byte, error) { return PackMessageNonceOutput(big.NewInt(7)) },
+				unpack:   func(b []byte) (interface{}, error) { return UnpackMessageNonceResult(b) },
+				expected: big.NewInt(7),
+			},
+			{
+				name:     "getNextMessageID",
+				pack:     func() ([]byte, error) { return PackGetNextMessageIDOutput(messageID) },
+				unpack:   func(b []byte) (interface{}, error) { return UnpackGetNextMessageIDResult(b) },
+				expected: messageID,
+			},
+			{
+				name:     "getMessageHash",
+				pack:     func() ([]byte, error) { return PackGetMessageHashOutput(messageHash) },
+				unpack:   func(b []byte) (interface{}, error) { return UnpackGetMessageHashResult(b) },
+				expected: messageHash,
+			},
+			{
+				name:     "getFeeInfo",
+				pack:     func() ([]byte, error) { return PackGetFeeInfoOutput(feeInfo) },
+				unpack:   func(b []byte) (interface{}, error) { return UnpackGetFeeInfoResult(b) },
+				expected: feeInfo,
+			},
+			{
+				name: "sentMessageInfo",
+				pack: func() ([]byte, error) { return PackSentMessageInfoOutput(messageHash, feeInfo) },
+				unpack: func(b []byte) (interface{}, error) {
+					hash, info, err := UnpackSentMessageInfoResult(b)
+					return []interface{}{hash, info}, err
+				},
+				expected: []interface{}{messageHash, feeInfo},
+			},
+			{
+				name:     "getRelayerRewardAddress",
+				pack:     func() ([]byte, error) { return PackGetRelayerRewardAddressOutput(address) },
+				unpack:   func(b []byte) (interface{}, error) { return UnpackGetRelayerRewardAddressResult(b) },
+				expected: address,
+			},
+			{
+				name:     "checkRelayerRewardAmount",
+				pack:     func() ([]byte, error) { return PackCheckRelayerRewardAmountOutput(big.NewInt(8)) },
+				unpack:   func(b []byte) (interface{}, error) { return UnpackCheckRelayerRewardAmountResult(b) },
+				expected: big.NewInt(8),
+			},
+			{
+				name:     "receivedFailedMessageHashes",
+				pack:     func() ([]byte, error) { return PackReceivedFailedMessageHashesOutput(messageHash) },
+				unpack:   func(b []byte) (interface{}, error) { return UnpackReceivedFailedMessageHashesResult(b) },
+				expected: messageHash,
+			},
+			{
+				name:     "getReceiptQueueSize",
+				pack:     func() ([]byte, error) { return PackGetReceiptQueueSizeOutput(big.NewInt(9)) },
+				unpack:   func(b []byte) (interface{}, error) { return UnpackGetReceiptQueueSizeResult(b) },
+				expected: big.NewInt(9),
+			},
+			{
+				name:     "getReceiptAtIndex",
+				pack:     func() ([]byte, error) { return PackGetReceiptAtIndexOutput(receipt) },
+				unpack:   func(b []byte) (interface{}, error) { return UnpackGetReceiptAtIndexResult(b) },
+				expected: receipt,
+			},
+			{
+				name: "receiptQueues",
+				pack: func() ([]byte, error) { return PackReceiptQueuesOutput(big.NewInt(1), big.NewInt(3)) },
+				unpack: func(b []byte) (interface{}, error) {
+					first, last, err := UnpackReceiptQueuesResult(b)
+					return []interface{}{first, last}, err
+				},
+				expected: []interface{}{big.NewInt(1), big.NewInt(3)},
+			},
+		}
+	)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, err := test.pack()
+			require.NoError(t, err)
+
+			out, err := test.unpack(b)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, out)
+		})
+	}
+
+	// Results that do not match the outputs of the method are rejected
+	_, err := UnpackGetFeeInfoResult([]byte{1, 2, 3})
+	require.Error(t, err)
+}
+
+func TestPackMethods(t *testing.T) {
+	teleporterABI, err := TeleporterMessengerMetaData.GetAbi()
+	require.NoError(t, err)
+
+	messageID := ids.ID{1, 2, 3}
+	address := common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+	var (
+		tests = []struct {
+			method string
+			pack   func() ([]byte, error)
+			args   []interface{}
+		}{
+			{"WARP_MESSENGER", PackWarpMessenger, []interface{}{}},
+			{"blockchainID", PackBlockchainID, []interface{}{}},
+			{"initializeBlockchainID", PackInitializeBlockchainID, []interface{}{}},
+			{"messageNonce", PackMessageNonce, []interface{}{}},
+			{
+				"getNextMessageID",
+				func() ([]byte, error) { return PackGetNextMessageID(messageID) },
+				[]interface{}{[32]byte(messageID)},
+			},
+			{
+				"getMessageHash",
+				func() ([]byte, error) { return PackGetMessageHash(messageID) },
+				[]interface{}{[32]byte(messageID)},
+			},
+			{
+				"getFeeInfo",
+				func() ([]byte, error) { return PackGetFeeInfo(messageID) },
+				[]interface{}{[32]byte(messageID)},
+			},
+			{
+				"sentMessageInfo",
+				func() ([]byte, error) { return PackSentMessageInfo(messageID) },
+				[]interface{}{[32]byte(messageID)},
+			},
+			{
+				"getRelayerRewardAddress",
+				func() ([]byte, error) { return PackGetRelayerRewardAddress(messageID) },
+				[]interface{}{[32]byte(messageID)},
+			},
+			{
+				"checkRelayerRewardAmount",
+				func() ([]byte, error) { return PackCheckRelayerRewardAmount(address, address) },
+				[]interface{}{address, address},
+			},
+			{
+				"receivedFailedMessageHashes",
+				func() ([]byte, error) { return PackReceivedFailedMessageHashes(messageID) },
+				[]interface{}{[32]byte(messageID)},
+			},
+			{
+				"getReceiptQueueSize",
+				func() ([]byte, error) { return PackGetReceiptQueueSize(messageID) },
+				[]interface{}{[32]byte(messageID)},
+			},
+			{
+				"getReceiptAtIndex",
+				func() ([]byte, error) { return PackGetReceiptAtIndex(messageID, big.NewInt(2)) },
+				[]interface{}{[32]byte(messageID), big.NewInt(2)},
+			},
+			{
+				"receiptQueues",
+				func() ([]byte, error) { return PackReceiptQueues(messageID) },
+				[]interface{}{[32]byte(messageID)},
+			},
+		}
+	)
+
+	for _, test := range tests {
+		t.Run(test.method, func(t *testing.T) {
+			b, err := test.pack()
+			require.NoError(t, err)
+
+			method := teleporterABI.Methods[test.method]
+			require.Equal(t, method.ID, b[:4])
+			args, err := method.Inputs.Unpack(b[4:])
+			require.NoError(t, err)
+			require.Equal(t, test.args, args)
+		})
+	}
+}
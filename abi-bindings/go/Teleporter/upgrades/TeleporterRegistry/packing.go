@@ -59,7 +59,6 @@ func UnpackTeleporterRegistryWarpPayload(entryBytes []byte) (ProtocolRegistryEnt
 		},
 	}
 	unpacked, err := args.Unpack(entryBytes)
-	fmt.Println("unpacked: ", unpacked)
 	if err != nil {
 		return ProtocolRegistryEntry{}, common.Address{}, fmt.Errorf("failed to unpack to Teleporter registry entry with err: %v", err)
 	}
@@ -85,3 +84,14 @@ func PackAddProtocolVersion(messageIndex uint32) ([]byte, error) {
 
 	return abi.Pack("addProtocolVersion", messageIndex)
 }
+
+// PackConstructor packs the constructor arguments of the TeleporterRegistry contract, to be
+// appended to its creation bytecode
+func PackConstructor(initialEntries []ProtocolRegistryEntry) ([]byte, error) {
+	abi, err := TeleporterRegistryMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get abi")
+	}
+
+	return abi.Pack("", initialEntries)
+}
@@ -28,3 +28,20 @@ func TestPackUnpackProtocolRegistryEntry(t *testing.T) {
 	require.Equal(t, entry.ProtocolAddress, unpackedEntry.ProtocolAddress)
 	require.Equal(t, destinationAddress, unpackedDestinationAddress)
 }
+
+func TestPackConstructor(t *testing.T) {
+	entry := ProtocolRegistryEntry{
+		Version:         big.NewInt(1),
+		ProtocolAddress: common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567"),
+	}
+
+	b, err := PackConstructor([]ProtocolRegistryEntry{entry})
+	require.NoError(t, err)
+
+	// Offset of the array, its length, and the version and address of the single entry
+	require.Len(t, b, 4*common.HashLength)
+	require.Equal(t, common.BigToHash(big.NewInt(common.HashLength)).Bytes(), b[:common.HashLength])
+	require.Equal(t, common.BigToHash(big.NewInt(1)).Bytes(), b[common.HashLength:2*common.HashLength])
+	require.Equal(t, common.BigToHash(entry.Version).Bytes(), b[2*common.HashLength:3*common.HashLength])
+	require.Equal(t, entry.ProtocolAddress, common.BytesToAddress(b[3*common.HashLength:]))
+}
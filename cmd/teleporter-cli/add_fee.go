@@ -0,0 +1,136 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	teleporterclient "github.com/ava-labs/teleporter/pkg/client"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	addFeeMessageID string
+	addFeeToken     string
+	addFeeAmount    string
+	addFeeGasLimit  uint64
+)
+
+// addFeeOutput is the result of adding to the relayer fee of a Teleporter message
+type addFeeOutput struct {
+	MessageID      ids.ID                                `json:"messageID"`
+	ApprovalTxHash common.Hash                           `json:"approvalTxHash,omitempty"`
+	TxHash         common.Hash                           `json:"txHash"`
+	FeeInfo        teleportermessenger.TeleporterFeeInfo `json:"feeInfo"`
+}
+
+var addFeeCmd = &cobra.Command{
+	Use: "add-fee --source-rpc SOURCE_RPC_URL --teleporter-address CONTRACT_ADDRESS " +
+		"--message-id MESSAGE_ID --fee-token TOKEN_ADDRESS --amount AMOUNT --key-file KEY_FILE [flags]",
+	Short: "Adds to the relayer fee of an undelivered Teleporter message",
+	Long: `Given the ID of a Teleporter message sent from the source chain, this command
+calls addFeeAmount on the source Teleporter contract to increase the relayer
+incentive for delivering the message. The fee token must match the one originally
+used for the message. If the Teleporter contract's allowance to spend the fee token
+on behalf of the sender is below the amount, an approval transaction is sent first.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		messageID, err := parseID(addFeeMessageID)
+		cobra.CheckErr(err)
+		feeToken, err := parseAddress(addFeeToken)
+		cobra.CheckErr(err)
+		amount, err := parseBigInt(addFeeAmount)
+		cobra.CheckErr(err)
+
+		out, err := addFeeAmountToMessage(context.Background(), messageID, feeToken, amount)
+		cobra.CheckErr(err)
+		cobra.CheckErr(printOutput(cmd, out))
+		cmd.Println("Add fee command ran successfully")
+	},
+}
+
+func addFeeAmountToMessage(
+	ctx context.Context,
+	messageID ids.ID,
+	feeToken common.Address,
+	amount *big.Int,
+) (*addFeeOutput, error) {
+	if amount.Sign() <= 0 {
+		return nil, errors.New("fee amount must be positive")
+	}
+	signer, err := newSigner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	source, err := newTeleporterClient(ctx, sourceClient, signer)
+	if err != nil {
+		return nil, err
+	}
+	sourceMessenger := source.Messenger()
+	opts := &bind.CallOpts{Context: ctx}
+
+	// Check the preconditions of addFeeAmount up front to report a clearer error than a reverted transaction.
+	messageHash, err := sourceMessenger.GetMessageHash(opts, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if messageHash == (common.Hash{}) {
+		return nil, errors.New("message not found, or its receipt has already been returned")
+	}
+	existingFeeToken, _, err := sourceMessenger.GetFeeInfo(opts, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if existingFeeToken != feeToken {
+		return nil, fmt.Errorf("fee token %s does not match the message's fee token %s", feeToken, existingFeeToken)
+	}
+
+	out := &addFeeOutput{MessageID: messageID}
+	approval, err := source.EnsureAllowance(ctx, feeToken, amount)
+	if err != nil {
+		return nil, err
+	}
+	if approval != nil {
+		out.ApprovalTxHash = approval.TxHash
+	}
+
+	receipt, err := source.AddFee(ctx, messageID, feeToken, amount, teleporterclient.WithGasLimit(addFeeGasLimit))
+	if err != nil {
+		return nil, err
+	}
+	out.TxHash = receipt.TxHash
+	for _, log := range receipt.Logs {
+		if event, err := sourceMessenger.ParseAddFeeAmount(*log); err == nil && event.MessageID == messageID {
+			out.FeeInfo = event.UpdatedFeeInfo
+		}
+	}
+	logger.Debug("Added fee amount",
+		zap.Stringer("messageID", messageID),
+		zap.String("sender", signer.Address().Hex()),
+		zap.String("amount", amount.String()))
+	return out, nil
+}
+
+func init() {
+	rootCmd.AddCommand(addFeeCmd)
+	addChainFlags(addFeeCmd, sourceChainLabel)
+	addFeeCmd.Flags().StringVar(&addFeeMessageID, "message-id", "", "ID of the message to add the fee to")
+	addFeeCmd.Flags().StringVar(&addFeeToken, "fee-token", "",
+		"Address of the ERC20 fee token, which must match the message's existing fee token")
+	addFeeCmd.Flags().StringVar(&addFeeAmount, "amount", "", "Amount of the fee token to add")
+	addSignerFlags(addFeeCmd, true)
+	addFeeCmd.Flags().Uint64Var(&addFeeGasLimit, "gas-limit", 0,
+		"Gas limit of the addFeeAmount transaction, estimated if not provided")
+	for _, flag := range []string{"message-id", "fee-token", "amount"} {
+		cobra.CheckErr(addFeeCmd.MarkFlagRequired(flag))
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddFeeCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"add-fee"},
+			err:  fmt.Errorf("required flag(s)"),
+		},
+		{
+			name: "help",
+			args: []string{"add-fee", "--help"},
+			err:  nil,
+			out:  "calls addFeeAmount on the source Teleporter contract",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
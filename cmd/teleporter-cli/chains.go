@@ -0,0 +1,96 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/spf13/cobra"
+)
+
+var (
+	discoverNodeURL   string
+	discoverNetwork   string
+	discoverSubnetIDs []string
+)
+
+var chainsCmd = &cobra.Command{
+	Use:   "chains",
+	Short: "Commands for managing the chains in the configuration file",
+	Long: `Commands for listing the chains in the configuration file, and for discovering the
+chains of a network from one of its nodes.`,
+	Args: cobra.NoArgs,
+}
+
+var chainsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the configured networks and chains",
+	Long:  `Lists the networks and chains defined in the configuration file.`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		// Blockchain IDs in the configuration are not annotated with themselves.
+		resolver = nil
+		cobra.CheckErr(printOutput(cmd, cliConfig))
+		cmd.Println("Chains list command ran successfully")
+	},
+}
+
+var chainsDiscoverCmd = &cobra.Command{
+	Use:   "discover --node-url NODE_URL --network NETWORK [--subnet-id SUBNET_ID]...",
+	Short: "Discovers the chains of a network from a node",
+	Long: `Given the URL of an Avalanche node, this command queries info.getBlockchainID and the
+P-Chain getBlockchains API for the blockchains of the network, optionally restricted to the
+given subnets. For each chain that serves the EVM JSON-RPC API on the node, the EVM chain
+ID and RPC and WebSocket endpoints are included. With --output yaml, the result can be
+merged into the configuration file.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		var subnetIDs []ids.ID
+		for _, s := range discoverSubnetIDs {
+			subnetID, err := parseID(s)
+			cobra.CheckErr(err)
+			subnetIDs = append(subnetIDs, subnetID)
+		}
+		out, err := discoverNetworkConfig(context.Background(), subnetIDs)
+		cobra.CheckErr(err)
+
+		resolver = nil
+		cobra.CheckErr(printOutput(cmd, out))
+		cmd.Println("Chains discover command ran successfully")
+	},
+}
+
+// discoverNetworkConfig returns a configuration containing the chains discovered from the node
+func discoverNetworkConfig(ctx context.Context, subnetIDs []ids.ID) (*Config, error) {
+	if discoverNetwork == "" {
+		return nil, errors.New("--network must not be empty")
+	}
+	chains, err := discoverChains(ctx, discoverNodeURL, discoverNetwork, subnetIDs)
+	if err != nil {
+		return nil, err
+	}
+	network := NetworkConfig{
+		NodeURL: discoverNodeURL,
+		Chains:  make(map[string]ChainConfig, len(chains)),
+	}
+	for _, chain := range chains {
+		network.Chains[chain.Name] = chain.ChainConfig
+	}
+	return &Config{Networks: map[string]NetworkConfig{discoverNetwork: network}}, nil
+}
+
+func init() {
+	rootCmd.AddCommand(chainsCmd)
+	chainsCmd.AddCommand(chainsListCmd)
+	chainsCmd.AddCommand(chainsDiscoverCmd)
+	chainsDiscoverCmd.Flags().StringVar(&discoverNodeURL, "node-url", "",
+		"URL of an Avalanche node, such as http://127.0.0.1:9650")
+	chainsDiscoverCmd.Flags().StringVar(&discoverNetwork, "network", "", "Name of the network in the configuration file")
+	chainsDiscoverCmd.Flags().StringSliceVar(&discoverSubnetIDs, "subnet-id", nil,
+		"Only discover the chains of this subnet, may be repeated")
+	cobra.CheckErr(chainsDiscoverCmd.MarkFlagRequired("node-url"))
+	cobra.CheckErr(chainsDiscoverCmd.MarkFlagRequired("network"))
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainsCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "discover no args",
+			args: []string{"chains", "discover"},
+			err:  fmt.Errorf("required flag(s) \"network\", \"node-url\" not set"),
+		},
+		{
+			name: "help",
+			args: []string{"chains", "--help"},
+			err:  nil,
+			out:  "Commands for listing the chains in the configuration file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+
+	"github.com/ava-labs/subnet-evm/ethclient"
+	teleporterclient "github.com/ava-labs/teleporter/pkg/client"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+const (
+	sourceChainLabel      = "source"
+	destinationChainLabel = "destination"
+
+	rpcFlag               = "rpc"
+	sourceRPCFlag         = "source-rpc"
+	destRPCFlag           = "dest-rpc"
+	teleporterAddressFlag = "teleporter-address"
+)
+
+var (
+	teleporterAddress common.Address
+	rpcEndpoint       string
+	client            ethclient.Client
+	sourceRPCEndpoint string
+	destRPCEndpoint   string
+	sourceClient      ethclient.Client
+	destClient        ethclient.Client
+)
+
+// addRPCFlags adds the flags required to connect to the Teleporter contract on a single chain,
+// and dials the chain before the command runs.
+func addRPCFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&rpcEndpoint, rpcFlag, "", "RPC endpoint to connect to the node")
+	address := cmd.PersistentFlags().StringP(teleporterAddressFlag, "t", "", "Teleporter contract address")
+	for _, flag := range []string{rpcFlag, teleporterAddressFlag} {
+		err := cmd.MarkPersistentFlagRequired(flag)
+		cobra.CheckErr(err)
+	}
+	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		return rpcPreRunE(cmd, args, address)
+	}
+}
+
+// addRPCEndpointFlag adds the flag required to connect to a single chain for commands that do not
+// use a deployed Teleporter contract, and dials the chain before the command runs.
+func addRPCEndpointFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&rpcEndpoint, rpcFlag, "", "RPC endpoint to connect to the node")
+	cobra.CheckErr(cmd.MarkPersistentFlagRequired(rpcFlag))
+	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		return rpcPreRunE(cmd, args, nil)
+	}
+}
+
+func rpcPreRunE(cmd *cobra.Command, args []string, address *string) error {
+	// Run the persistent pre-run function of the root command if it exists.
+	if err := callPersistentPreRunE(cmd, args); err != nil {
+		return err
+	}
+	if err := setChainFlagDefaults(cmd, selectedChain, rpcFlag); err != nil {
+		return err
+	}
+	// Required flags are otherwise only validated after the pre-run functions.
+	if err := cmd.ValidateRequiredFlags(); err != nil {
+		return err
+	}
+	if address != nil {
+		teleporterAddress = common.HexToAddress(*address)
+	}
+	c, err := ethclient.Dial(rpcEndpoint)
+	if err != nil {
+		return err
+	}
+	client = c
+	return nil
+}
+
+// addSourceDestinationFlags adds the flags required to connect to the Teleporter contract
+// on both the source and destination chain of a message, and dials both chains before
+// the command runs.
+func addSourceDestinationFlags(cmd *cobra.Command) {
+	addChainFlags(cmd, sourceChainLabel, destinationChainLabel)
+}
+
+// addChainFlags adds the flags required to connect to the Teleporter contract on each of
+// the given chains, and dials the chains before the command runs. The chain selected with
+// --chain configures the first of the given chains, and commands using both the source and
+// destination chain select the configured destination chain with --destination-chain.
+func addChainFlags(cmd *cobra.Command, chains ...string) {
+	required := []string{teleporterAddressFlag}
+	for _, chain := range chains {
+		switch chain {
+		case sourceChainLabel:
+			cmd.PersistentFlags().StringVar(&sourceRPCEndpoint, sourceRPCFlag, "",
+				"RPC endpoint of the source chain")
+			required = append(required, sourceRPCFlag)
+		case destinationChainLabel:
+			cmd.PersistentFlags().StringVar(&destRPCEndpoint, destRPCFlag, "",
+				"RPC endpoint of the destination chain")
+			required = append(required, destRPCFlag)
+		}
+	}
+	address := cmd.PersistentFlags().StringP(teleporterAddressFlag, "t", "", "Teleporter contract address")
+	for _, flag := range required {
+		err := cmd.MarkPersistentFlagRequired(flag)
+		cobra.CheckErr(err)
+	}
+	var destinationChain *string
+	if len(chains) > 1 {
+		destinationChain = cmd.PersistentFlags().String(destinationChainFlag, "",
+			"Name of the configured destination chain to use, as NETWORK/CHAIN or CHAIN")
+	}
+	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		return chainPreRunE(cmd, args, address, destinationChain, chains)
+	}
+}
+
+func chainPreRunE(
+	cmd *cobra.Command,
+	args []string,
+	address *string,
+	destinationChain *string,
+	chains []string,
+) error {
+	// Run the persistent pre-run function of the root command if it exists.
+	if err := callPersistentPreRunE(cmd, args); err != nil {
+		return err
+	}
+	for i, chain := range chains {
+		configured := selectedChain
+		if i > 0 {
+			configured = nil
+			if *destinationChain != "" {
+				var err error
+				if configured, err = cliConfig.Chain(*destinationChain); err != nil {
+					return err
+				}
+			}
+		}
+		rpcFlagName := sourceRPCFlag
+		if chain == destinationChainLabel {
+			rpcFlagName = destRPCFlag
+		}
+		if err := setChainFlagDefaults(cmd, configured, rpcFlagName); err != nil {
+			return err
+		}
+	}
+	// Required flags are otherwise only validated after the pre-run functions.
+	if err := cmd.ValidateRequiredFlags(); err != nil {
+		return err
+	}
+	teleporterAddress = common.HexToAddress(*address)
+	for _, chain := range chains {
+		switch chain {
+		case sourceChainLabel:
+			c, err := ethclient.Dial(sourceRPCEndpoint)
+			if err != nil {
+				return err
+			}
+			sourceClient = c
+		case destinationChainLabel:
+			c, err := ethclient.Dial(destRPCEndpoint)
+			if err != nil {
+				return err
+			}
+			destClient = c
+		}
+	}
+	return nil
+}
+
+// newTeleporterClient returns a client for the Teleporter contract on the chain connected to by the given
+// client. The signer may be nil for commands that only make queries.
+func newTeleporterClient(
+	ctx context.Context,
+	client ethclient.Client,
+	signer teleporterclient.Signer,
+) (*teleporterclient.Client, error) {
+	return teleporterclient.New(ctx, client, teleporterAddress, signer)
+}
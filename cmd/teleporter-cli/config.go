@@ -0,0 +1,212 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	configFlag           = "config"
+	chainFlag            = "chain"
+	destinationChainFlag = "destination-chain"
+	discoverChainsFlag   = "discover-chains"
+
+	defaultConfigDir  = ".teleporter"
+	defaultConfigFile = "config.yaml"
+)
+
+var (
+	cliConfig *Config
+	// The chain selected by the --chain flag, if any
+	selectedChain *Chain
+)
+
+// Config is the teleporter-cli configuration file. It defines named networks, each
+// containing named chains, so that commands can select a chain with --chain instead of
+// repeating its RPC endpoint and contract addresses.
+type Config struct {
+	Networks map[string]NetworkConfig `yaml:"networks" json:"networks"`
+}
+
+// NetworkConfig is a named network, such as local, fuji or mainnet. If a node URL is
+// given, blockchain IDs that are not configured are looked up from the node's P-Chain.
+type NetworkConfig struct {
+	NodeURL string                 `yaml:"nodeURL" json:"nodeURL,omitempty"`
+	Chains  map[string]ChainConfig `yaml:"chains" json:"chains"`
+}
+
+// ChainConfig holds the endpoints and contract addresses of a chain
+type ChainConfig struct {
+	BlockchainID      ids.ID         `yaml:"blockchainID" json:"blockchainID"`
+	SubnetID          ids.ID         `yaml:"subnetID" json:"subnetID"`
+	EVMChainID        uint64         `yaml:"evmChainID" json:"evmChainID,omitempty"`
+	RPCURL            string         `yaml:"rpcURL" json:"rpcURL,omitempty"`
+	WSURL             string         `yaml:"wsURL" json:"wsURL,omitempty"`
+	TeleporterAddress common.Address `yaml:"teleporterAddress" json:"teleporterAddress,omitempty"`
+	RegistryAddress   common.Address `yaml:"registryAddress" json:"registryAddress,omitempty"`
+}
+
+// UnmarshalYAML decodes a chain from the configuration file. Blockchain and subnet IDs are
+// written in CB58, and addresses in hex.
+func (c *ChainConfig) UnmarshalYAML(node *yaml.Node) error {
+	var raw struct {
+		BlockchainID      string `yaml:"blockchainID"`
+		SubnetID          string `yaml:"subnetID"`
+		EVMChainID        uint64 `yaml:"evmChainID"`
+		RPCURL            string `yaml:"rpcURL"`
+		WSURL             string `yaml:"wsURL"`
+		TeleporterAddress string `yaml:"teleporterAddress"`
+		RegistryAddress   string `yaml:"registryAddress"`
+	}
+	if err := node.Decode(&raw); err != nil {
+		return err
+	}
+	*c = ChainConfig{
+		EVMChainID: raw.EVMChainID,
+		RPCURL:     raw.RPCURL,
+		WSURL:      raw.WSURL,
+	}
+	var err error
+	if raw.BlockchainID != "" {
+		if c.BlockchainID, err = ids.FromString(raw.BlockchainID); err != nil {
+			return fmt.Errorf("invalid blockchainID %q: %w", raw.BlockchainID, err)
+		}
+	}
+	if raw.SubnetID != "" {
+		if c.SubnetID, err = ids.FromString(raw.SubnetID); err != nil {
+			return fmt.Errorf("invalid subnetID %q: %w", raw.SubnetID, err)
+		}
+	}
+	if raw.TeleporterAddress != "" {
+		if c.TeleporterAddress, err = parseAddress(raw.TeleporterAddress); err != nil {
+			return err
+		}
+	}
+	if raw.RegistryAddress != "" {
+		if c.RegistryAddress, err = parseAddress(raw.RegistryAddress); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Chain is a chain in the configuration file along with its network and name
+type Chain struct {
+	Network string
+	Name    string
+	ChainConfig
+}
+
+// String returns the fully qualified name of the chain
+func (c *Chain) String() string {
+	return c.Network + "/" + c.Name
+}
+
+// defaultConfigPath returns the path of the configuration file in the user's home directory
+func defaultConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, defaultConfigDir, defaultConfigFile), nil
+}
+
+// loadConfig reads the configuration file at the given path, or at the default path if
+// the path is empty. A missing configuration file at the default path is not an error.
+func loadConfig(path string) (*Config, error) {
+	explicit := path != ""
+	if !explicit {
+		var err error
+		if path, err = defaultConfigPath(); err != nil {
+			return &Config{}, nil
+		}
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
+			return &Config{}, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	config := &Config{}
+	if err := yaml.Unmarshal(b, config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// Chain returns the chain with the given name. The name is either NETWORK/CHAIN, or the
+// name of a chain that only exists in a single network.
+func (c *Config) Chain(name string) (*Chain, error) {
+	if network, chainName, ok := strings.Cut(name, "/"); ok {
+		chain, ok := c.Networks[network].Chains[chainName]
+		if !ok {
+			return nil, fmt.Errorf("unknown chain %s", name)
+		}
+		return &Chain{Network: network, Name: chainName, ChainConfig: chain}, nil
+	}
+
+	var matches []*Chain
+	for network, networkConfig := range c.Networks {
+		if chain, ok := networkConfig.Chains[name]; ok {
+			matches = append(matches, &Chain{Network: network, Name: name, ChainConfig: chain})
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("unknown chain %s", name)
+	case 1:
+		return matches[0], nil
+	default:
+		networks := make([]string, 0, len(matches))
+		for _, match := range matches {
+			networks = append(networks, match.Network)
+		}
+		sort.Strings(networks)
+		return nil, fmt.Errorf("chain %s exists in multiple networks (%s), use NETWORK/CHAIN",
+			name, strings.Join(networks, ", "))
+	}
+}
+
+// setFlagDefault sets a flag that was not set on the command line to a value from the
+// configuration file, so that the flag counts as set when required flags are validated.
+func setFlagDefault(cmd *cobra.Command, name string, value string) error {
+	f := cmd.Flags().Lookup(name)
+	if f == nil || f.Changed || value == "" {
+		return nil
+	}
+	return cmd.Flags().Set(name, value)
+}
+
+// setChainFlagDefaults sets the RPC endpoint and Teleporter address flags of a command from a
+// configured chain. Flags set on the command line take precedence.
+func setChainFlagDefaults(cmd *cobra.Command, chain *Chain, rpcFlagName string) error {
+	if chain == nil {
+		return nil
+	}
+	if err := setFlagDefault(cmd, rpcFlagName, chain.RPCURL); err != nil {
+		return err
+	}
+	return setTeleporterAddressDefault(cmd, chain)
+}
+
+// setTeleporterAddressDefault sets the Teleporter address flag of a command from a configured chain
+// if it was not set on the command line.
+func setTeleporterAddressDefault(cmd *cobra.Command, chain *Chain) error {
+	if chain == nil || chain.TeleporterAddress == (common.Address{}) {
+		return nil
+	}
+	return setFlagDefault(cmd, teleporterAddressFlag, chain.TeleporterAddress.Hex())
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestConfig(t *testing.T, contents string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	blockchainID := ids.GenerateTestID()
+	subnetID := ids.GenerateTestID()
+	path := writeTestConfig(t, `
+networks:
+  fuji:
+    chains:
+      dispatch:
+        blockchainID: `+blockchainID.String()+`
+        subnetID: `+subnetID.String()+`
+        rpcURL: https://example.com/rpc
+        wsURL: wss://example.com/ws
+        teleporterAddress: "0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf"
+        registryAddress: "0x0000000000000000000000000000000000000001"
+`)
+	config, err := loadConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, ChainConfig{
+		BlockchainID:      blockchainID,
+		SubnetID:          subnetID,
+		RPCURL:            "https://example.com/rpc",
+		WSURL:             "wss://example.com/ws",
+		TeleporterAddress: common.HexToAddress("0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf"),
+		RegistryAddress:   common.HexToAddress("0x01"),
+	}, config.Networks["fuji"].Chains["dispatch"])
+
+	_, err = loadConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.ErrorContains(t, err, "failed to read config file")
+
+	_, err = loadConfig(writeTestConfig(t, "networks: [1, 2]"))
+	require.ErrorContains(t, err, "failed to parse config file")
+
+	_, err = loadConfig(writeTestConfig(t, `
+networks:
+  local:
+    chains:
+      subnet-a:
+        blockchainID: not-an-id
+`))
+	require.ErrorContains(t, err, "invalid blockchainID \"not-an-id\"")
+}
+
+func TestConfigChain(t *testing.T) {
+	config := &Config{
+		Networks: map[string]NetworkConfig{
+			"local": {Chains: map[string]ChainConfig{
+				"c-chain":  {RPCURL: "local-c"},
+				"subnet-a": {RPCURL: "local-a"},
+			}},
+			"fuji": {Chains: map[string]ChainConfig{
+				"c-chain": {RPCURL: "fuji-c"},
+			}},
+		},
+	}
+
+	var tests = []struct {
+		name     string
+		expected string
+		err      string
+	}{
+		{name: "fuji/c-chain", expected: "fuji-c"},
+		{name: "subnet-a", expected: "local-a"},
+		{name: "c-chain", err: "chain c-chain exists in multiple networks (fuji, local), use NETWORK/CHAIN"},
+		{name: "fuji/subnet-a", err: "unknown chain fuji/subnet-a"},
+		{name: "mainnet/c-chain", err: "unknown chain mainnet/c-chain"},
+		{name: "subnet-b", err: "unknown chain subnet-b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain, err := config.Chain(tt.name)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, chain.RPCURL)
+		})
+	}
+}
+
+func TestSetChainFlagDefaults(t *testing.T) {
+	chain := &Chain{
+		Network: "local",
+		Name:    "subnet-a",
+		ChainConfig: ChainConfig{
+			RPCURL:            "http://127.0.0.1:9650/ext/bc/subnet-a/rpc",
+			TeleporterAddress: common.HexToAddress("0x01"),
+		},
+	}
+
+	cmd := &cobra.Command{}
+	rpc := cmd.Flags().String(rpcFlag, "", "")
+	address := cmd.Flags().String(teleporterAddressFlag, "", "")
+	require.NoError(t, cmd.Flags().Parse([]string{"--" + teleporterAddressFlag, "0x02"}))
+
+	require.NoError(t, setChainFlagDefaults(cmd, chain, rpcFlag))
+	require.Equal(t, chain.RPCURL, *rpc)
+	require.True(t, cmd.Flags().Lookup(rpcFlag).Changed)
+	// Flags set on the command line are not overridden.
+	require.Equal(t, "0x02", *address)
+
+	require.NoError(t, setChainFlagDefaults(cmd, nil, rpcFlag))
+	require.Equal(t, chain.RPCURL, *rpc)
+}
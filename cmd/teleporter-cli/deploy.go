@@ -0,0 +1,421 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	teleporterregistry "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/upgrades/TeleporterRegistry"
+	deploymentUtils "github.com/ava-labs/teleporter/utils/deployment-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const (
+	messengerDeploymentTxArtifact    = "TeleporterMessenger_Deployment_Transaction"
+	messengerContractAddressArtifact = "TeleporterMessenger_Contract_Address"
+	registryBytecodeArtifact         = "TeleporterRegistry_Bytecode"
+	defaultTeleporterReleaseURL      = "https://github.com/ava-labs/teleporter/releases/download"
+	maxReleaseArtifactSize           = 1 << 20
+
+	deployVersionFlag      = "version"
+	deployBytecodeFileFlag = "bytecode-file"
+
+	initialRegistryProtocolVersion = 1
+)
+
+var (
+	deployVersion      string
+	deployBytecodeFile string
+	deployGasPrice     string
+	deployGasLimit     uint64
+	deployMessenger    string
+
+	// The URL release artifacts are downloaded from, followed by /VERSION/NAME_VERSION.txt
+	teleporterReleaseURL = defaultTeleporterReleaseURL
+)
+
+// deployOutput is the result of deploying a contract
+type deployOutput struct {
+	ContractAddress common.Address  `json:"contractAddress"`
+	DeployerAddress common.Address  `json:"deployerAddress"`
+	AlreadyDeployed bool            `json:"alreadyDeployed"`
+	FundingAmount   *big.Int        `json:"fundingAmount,omitempty"`
+	FundingTxHash   common.Hash     `json:"fundingTxHash,omitempty"`
+	TxHash          common.Hash     `json:"txHash,omitempty"`
+	Verified        bool            `json:"verified"`
+	Messenger       *common.Address `json:"messenger,omitempty"`
+}
+
+// keylessDeployment is a Nick's method contract creation transaction, along with its deployer
+// and the address of the contract it creates
+type keylessDeployment struct {
+	tx              *types.Transaction
+	deployerAddress common.Address
+	contractAddress common.Address
+}
+
+var deployCmd = &cobra.Command{
+	Use:   "deploy",
+	Short: "Commands for deploying the Teleporter contracts",
+	Long: `Commands for deploying the TeleporterMessenger and TeleporterRegistry contracts to a
+chain, from a release version or a forge build artifact.`,
+	Args: cobra.NoArgs,
+}
+
+var deployMessengerCmd = &cobra.Command{
+	Use:   "messenger --rpc RPC_URL (--version VERSION | --bytecode-file BYTECODE_FILE)",
+	Short: "Deploys the TeleporterMessenger contract using Nick's method",
+	Long: `Deploys the TeleporterMessenger contract to the same address as on every other chain,
+with a keyless transaction using Nick's method. The transaction is either downloaded from
+the release with --version, or constructed from the bytecode in a forge build artifact.
+If the contract is already deployed, nothing is sent. Otherwise the deployment is first
+simulated, and if the balance of the deployer address is below the cost of the deployment,
+the deployer is funded with exactly the missing amount by the given signer. After the
+transaction is accepted, the runtime bytecode at the contract address is verified against
+the simulated deployment.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		out, err := deployTeleporterMessenger(context.Background())
+		cobra.CheckErr(err)
+		cobra.CheckErr(printOutput(cmd, out))
+		cmd.Println("Deploy messenger command ran successfully")
+	},
+}
+
+var deployRegistryCmd = &cobra.Command{
+	Use: "registry --rpc RPC_URL (--version VERSION | --bytecode-file BYTECODE_FILE) " +
+		"[--teleporter-address CONTRACT_ADDRESS] --key-file KEY_FILE",
+	Short: "Deploys the TeleporterRegistry contract",
+	Long: `Deploys a TeleporterRegistry contract, with the TeleporterMessenger contract at the
+given address registered as protocol version 1. The registry bytecode is either downloaded
+from the release with --version, or read from a forge build artifact. With --version, the
+TeleporterMessenger address defaults to the address of that release. The registry does not
+need to be at the same address on every chain, so it is deployed by the given signer. After
+the transaction is accepted, the runtime bytecode at the contract address is verified
+against the simulated deployment.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		out, err := deployTeleporterRegistry(context.Background())
+		cobra.CheckErr(err)
+		cobra.CheckErr(printOutput(cmd, out))
+		cmd.Println("Deploy registry command ran successfully")
+	},
+}
+
+func deployTeleporterMessenger(ctx context.Context) (*deployOutput, error) {
+	deployment, err := messengerDeployment(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := &deployOutput{
+		ContractAddress: deployment.contractAddress,
+		DeployerAddress: deployment.deployerAddress,
+	}
+
+	code, err := client.CodeAt(ctx, deployment.contractAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(code) > 0 {
+		logger.Info("TeleporterMessenger is already deployed",
+			zap.String("contractAddress", deployment.contractAddress.Hex()))
+		out.AlreadyDeployed = true
+		return out, nil
+	}
+	nonce, err := client.NonceAt(ctx, deployment.deployerAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	if nonce != 0 {
+		return nil, fmt.Errorf("deployer address %s has already sent a transaction, and can not deploy to %s",
+			deployment.deployerAddress, deployment.contractAddress)
+	}
+
+	runtimeCode, err := simulateDeployment(ctx, deployment.deployerAddress, deployment.tx.Data(), deployment.tx.Gas())
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := client.BalanceAt(ctx, deployment.deployerAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	if required := deployment.tx.Cost(); balance.Cmp(required) < 0 {
+		out.FundingAmount = new(big.Int).Sub(required, balance)
+		if !signerOpts.isSet() {
+			return nil, fmt.Errorf("%w to fund the deployer address, or fund %s with %s wei",
+				errNoSigner, deployment.deployerAddress, out.FundingAmount)
+		}
+		signer, err := newSigner(ctx)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("Funding deployer address",
+			zap.String("deployerAddress", deployment.deployerAddress.Hex()),
+			zap.String("amount", out.FundingAmount.String()))
+		receipt, err := sendTransaction(ctx, client, signer, deployment.deployerAddress, nil, out.FundingAmount, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fund deployer address: %w", err)
+		}
+		out.FundingTxHash = receipt.TxHash
+	}
+
+	receipt, err := sendSignedTransaction(ctx, client, deployment.tx, deployment.deployerAddress)
+	if err != nil {
+		return nil, err
+	}
+	out.TxHash = receipt.TxHash
+	if err := verifyRuntimeCode(ctx, deployment.contractAddress, runtimeCode); err != nil {
+		return nil, err
+	}
+	out.Verified = true
+	return out, nil
+}
+
+// messengerDeployment returns the keyless transaction deploying the TeleporterMessenger contract,
+// either from the release given by --version or from the bytecode in --bytecode-file
+func messengerDeployment(ctx context.Context) (*keylessDeployment, error) {
+	if deployBytecodeFile != "" {
+		gasPrice, err := parseBigInt(deployGasPrice)
+		if err != nil {
+			return nil, err
+		}
+		byteCode, err := deploymentUtils.ExtractByteCode(deployBytecodeFile)
+		if err != nil {
+			return nil, err
+		}
+		keyless, err := deploymentUtils.ConstructKeylessDeployment(deploymentUtils.KeylessTransactionParams{
+			ByteCode: byteCode,
+			GasPrice: gasPrice,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &keylessDeployment{
+			tx:              keyless.Transaction,
+			deployerAddress: keyless.DeployerAddress,
+			contractAddress: keyless.ContractAddress,
+		}, nil
+	}
+
+	txHex, err := fetchReleaseArtifact(ctx, deployVersion, messengerDeploymentTxArtifact)
+	if err != nil {
+		return nil, err
+	}
+	txBytes, err := parseHexBytes(txHex)
+	if err != nil {
+		return nil, err
+	}
+	deployment, err := decodeKeylessTransaction(txBytes)
+	if err != nil {
+		return nil, err
+	}
+	releaseAddress, err := releaseMessengerAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if releaseAddress != deployment.contractAddress {
+		return nil, fmt.Errorf("release %s deployment transaction creates %s instead of %s",
+			deployVersion, deployment.contractAddress, releaseAddress)
+	}
+	return deployment, nil
+}
+
+// decodeKeylessTransaction decodes the Nick's method transaction of a release, and derives its
+// deployer and the address of the contract it creates
+func decodeKeylessTransaction(txBytes []byte) (*keylessDeployment, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(txBytes); err != nil {
+		return nil, fmt.Errorf("failed to decode deployment transaction: %w", err)
+	}
+	if tx.To() != nil || tx.Nonce() != 0 {
+		return nil, errors.New("deployment transaction is not a contract creation with nonce 0")
+	}
+	// Keyless transactions are not replay protected, so that they are valid on every chain.
+	deployerAddress, err := types.HomesteadSigner{}.Sender(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover deployer address: %w", err)
+	}
+	contractAddress, err := deploymentUtils.DeriveEVMContractAddress(deployerAddress, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &keylessDeployment{
+		tx:              tx,
+		deployerAddress: deployerAddress,
+		contractAddress: contractAddress,
+	}, nil
+}
+
+func deployTeleporterRegistry(ctx context.Context) (*deployOutput, error) {
+	bytecode, err := registryBytecode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	messenger, err := registryMessenger(ctx)
+	if err != nil {
+		return nil, err
+	}
+	code, err := client.CodeAt(ctx, messenger, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(code) == 0 {
+		return nil, fmt.Errorf("no TeleporterMessenger contract is deployed at %s", messenger)
+	}
+	constructorArgs, err := teleporterregistry.PackConstructor([]teleporterregistry.ProtocolRegistryEntry{
+		{
+			Version:         big.NewInt(initialRegistryProtocolVersion),
+			ProtocolAddress: messenger,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	data := append(bytecode, constructorArgs...)
+
+	signer, err := newSigner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	runtimeCode, err := simulateDeployment(ctx, signer.Address(), data, deployGasLimit)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := deployContract(ctx, client, signer, data, deployGasLimit)
+	if err != nil {
+		return nil, err
+	}
+	out := &deployOutput{
+		ContractAddress: receipt.ContractAddress,
+		DeployerAddress: signer.Address(),
+		TxHash:          receipt.TxHash,
+		Messenger:       &messenger,
+	}
+	if err := verifyRuntimeCode(ctx, receipt.ContractAddress, runtimeCode); err != nil {
+		return nil, err
+	}
+	out.Verified = true
+	return out, nil
+}
+
+// registryBytecode returns the TeleporterRegistry creation bytecode, either from the release given by
+// --version or from --bytecode-file
+func registryBytecode(ctx context.Context) ([]byte, error) {
+	if deployBytecodeFile != "" {
+		return deploymentUtils.ExtractByteCode(deployBytecodeFile)
+	}
+	bytecodeHex, err := fetchReleaseArtifact(ctx, deployVersion, registryBytecodeArtifact)
+	if err != nil {
+		return nil, err
+	}
+	return parseHexBytes(bytecodeHex)
+}
+
+// registryMessenger returns the address of the TeleporterMessenger contract registered in a new registry
+func registryMessenger(ctx context.Context) (common.Address, error) {
+	if deployMessenger != "" {
+		return parseAddress(deployMessenger)
+	}
+	if deployVersion == "" {
+		return common.Address{}, fmt.Errorf("--%s is required with --%s", teleporterAddressFlag, deployBytecodeFileFlag)
+	}
+	return releaseMessengerAddress(ctx)
+}
+
+func releaseMessengerAddress(ctx context.Context) (common.Address, error) {
+	address, err := fetchReleaseArtifact(ctx, deployVersion, messengerContractAddressArtifact)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return parseAddress(address)
+}
+
+// fetchReleaseArtifact downloads a text artifact of a Teleporter release
+func fetchReleaseArtifact(ctx context.Context, version string, name string) (string, error) {
+	url := fmt.Sprintf("%s/%s/%s_%s.txt", teleporterReleaseURL, version, name, version)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s not found for release %s: %s", name, version, resp.Status)
+	}
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxReleaseArtifactSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	logger.Debug("Downloaded release artifact", zap.String("url", url))
+	return strings.TrimSpace(string(b)), nil
+}
+
+// simulateDeployment simulates a contract creation with eth_call, and returns the runtime bytecode of the
+// created contract. This reports deployments that would revert before any transaction is sent.
+func simulateDeployment(ctx context.Context, from common.Address, data []byte, gasLimit uint64) ([]byte, error) {
+	runtimeCode, err := client.CallContract(ctx, interfaces.CallMsg{
+		From: from,
+		Gas:  gasLimit,
+		Data: data,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate deployment: %w", err)
+	}
+	if len(runtimeCode) == 0 {
+		return nil, errors.New("simulated deployment created a contract without code")
+	}
+	return runtimeCode, nil
+}
+
+// verifyRuntimeCode checks that the code at the contract address matches the simulated deployment
+func verifyRuntimeCode(ctx context.Context, contractAddress common.Address, expected []byte) error {
+	code, err := client.CodeAt(ctx, contractAddress, nil)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(code, expected) {
+		return fmt.Errorf("runtime bytecode at %s does not match the simulated deployment", contractAddress)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(deployCmd)
+	deployCmd.AddCommand(deployMessengerCmd)
+	deployCmd.AddCommand(deployRegistryCmd)
+	for _, cmd := range []*cobra.Command{deployMessengerCmd, deployRegistryCmd} {
+		addRPCEndpointFlag(cmd)
+		cmd.Flags().StringVar(&deployVersion, deployVersionFlag, "",
+			"Teleporter release version to deploy, such as v1.0.0")
+		cmd.Flags().StringVar(&deployBytecodeFile, deployBytecodeFileFlag, "",
+			"Forge build artifact containing the contract bytecode")
+		cmd.MarkFlagsOneRequired(deployVersionFlag, deployBytecodeFileFlag)
+		cmd.MarkFlagsMutuallyExclusive(deployVersionFlag, deployBytecodeFileFlag)
+	}
+	addSignerFlags(deployMessengerCmd, false)
+	deployMessengerCmd.Flags().StringVar(&deployGasPrice, "gas-price",
+		deploymentUtils.GetDefaultContractCreationGasPrice().String(),
+		"Gas price in wei of the keyless transaction constructed from --bytecode-file")
+
+	addSignerFlags(deployRegistryCmd, true)
+	deployRegistryCmd.Flags().StringVarP(&deployMessenger, teleporterAddressFlag, "t", "",
+		"Address of the TeleporterMessenger registered as version 1, the address of --version if not provided")
+	deployRegistryCmd.Flags().Uint64Var(&deployGasLimit, "gas-limit", 0,
+		"Gas limit of the deployment transaction, estimated if not provided")
+}
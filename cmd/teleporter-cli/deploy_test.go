@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	deploymentUtils "github.com/ava-labs/teleporter/utils/deployment-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeployCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "messenger no args",
+			args: []string{"deploy", "messenger"},
+			err:  fmt.Errorf("required flag(s) \"rpc\" not set"),
+		},
+		{
+			name: "registry no args",
+			args: []string{"deploy", "registry"},
+			err:  fmt.Errorf("required flag(s) \"rpc\" not set"),
+		},
+		{
+			name: "help",
+			args: []string{"deploy", "messenger", "--help"},
+			err:  nil,
+			out:  "with a keyless transaction using Nick's method",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestDecodeKeylessTransaction(t *testing.T) {
+	bytecodeFile := filepath.Join(t.TempDir(), "Contract.json")
+	require.NoError(t, os.WriteFile(bytecodeFile, []byte(`{"bytecode":{"object":"0x6080604052"}}`), 0o600))
+	txBytes, deployerAddress, contractAddress, err := deploymentUtils.ConstructKeylessTransaction(
+		bytecodeFile,
+		false,
+		deploymentUtils.GetDefaultContractCreationGasPrice(),
+	)
+	require.NoError(t, err)
+
+	deployment, err := decodeKeylessTransaction(txBytes)
+	require.NoError(t, err)
+	require.Equal(t, deployerAddress, deployment.deployerAddress)
+	require.Equal(t, contractAddress, deployment.contractAddress)
+	require.Equal(t, []byte{0x60, 0x80, 0x60, 0x40, 0x52}, deployment.tx.Data())
+
+	to := common.HexToAddress("0x01")
+	callTx, err := types.NewTx(&types.LegacyTx{To: &to}).MarshalBinary()
+	require.NoError(t, err)
+	_, err = decodeKeylessTransaction(callTx)
+	require.ErrorContains(t, err, "not a contract creation")
+}
+
+func TestFetchReleaseArtifact(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1.0.0/TeleporterMessenger_Contract_Address_v1.0.0.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintln(w, "0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf")
+	}))
+	defer server.Close()
+	teleporterReleaseURL = server.URL
+	defer func() { teleporterReleaseURL = defaultTeleporterReleaseURL }()
+
+	address, err := fetchReleaseArtifact(context.Background(), "v1.0.0", messengerContractAddressArtifact)
+	require.NoError(t, err)
+	require.Equal(t, "0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf", address)
+
+	_, err = fetchReleaseArtifact(context.Background(), "v9.9.9", messengerContractAddressArtifact)
+	require.ErrorContains(t, err, "TeleporterMessenger_Contract_Address not found for release v9.9.9")
+}
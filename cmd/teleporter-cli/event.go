@@ -7,7 +7,6 @@ import (
 	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
-	"go.uber.org/zap"
 )
 
 var (
@@ -15,6 +14,12 @@ var (
 	data      []byte
 )
 
+// eventOutput is a decoded Teleporter event along with its name
+type eventOutput struct {
+	Name  string      `json:"name"`
+	Event interface{} `json:"event"`
+}
+
 var eventCmd = &cobra.Command{
 	Use:   "event --topics topic1,topic2 [--data data]",
 	Short: "Parses a Teleporter log's topics and data",
@@ -36,7 +41,7 @@ func eventRun(cmd *cobra.Command, args []string) {
 
 	out, err := teleportermessenger.FilterTeleporterEvents(topics, data, event.Name)
 	cobra.CheckErr(err)
-	logger.Info("Parsed Teleporter event", zap.String("name", event.Name), zap.Any("event", out))
+	cobra.CheckErr(printOutput(cmd, eventOutput{Name: event.Name, Event: out}))
 	cmd.Println("Event command ran successfully for", event.Name)
 }
 
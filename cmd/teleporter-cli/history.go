@@ -0,0 +1,397 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// HistoryFormat is the format of the rows exported by the history command
+type HistoryFormat uint8
+
+const (
+	CSVHistory HistoryFormat = iota
+	JSONHistory
+	NDJSONHistory
+
+	csvHistoryStr    = "csv"
+	jsonHistoryStr   = "json"
+	ndjsonHistoryStr = "ndjson"
+
+	defaultHistoryPageSize = 2048
+)
+
+var (
+	historyFromBlock uint64
+	historyToBlock   uint64
+	historyFormat    string
+	historyPageSize  uint64
+)
+
+// String returns the string representation of a HistoryFormat
+func (f HistoryFormat) String() string {
+	switch f {
+	case JSONHistory:
+		return jsonHistoryStr
+	case NDJSONHistory:
+		return ndjsonHistoryStr
+	default:
+		return csvHistoryStr
+	}
+}
+
+// ToHistoryFormat converts a string to a HistoryFormat
+func ToHistoryFormat(s string) (HistoryFormat, error) {
+	switch strings.ToLower(s) {
+	case csvHistoryStr:
+		return CSVHistory, nil
+	case jsonHistoryStr:
+		return JSONHistory, nil
+	case ndjsonHistoryStr:
+		return NDJSONHistory, nil
+	default:
+		return CSVHistory, fmt.Errorf("unknown history format %s", s)
+	}
+}
+
+// historyRow is a single Teleporter event. Fields that do not apply to the event are left unset.
+// The fee is the message fee for message events, and the redeemed amount for reward redemptions.
+type historyRow struct {
+	BlockNumber             uint64          `json:"blockNumber"`
+	TxHash                  common.Hash     `json:"txHash"`
+	LogIndex                uint            `json:"logIndex"`
+	Event                   string          `json:"event"`
+	MessageID               *ids.ID         `json:"messageID"`
+	CounterpartBlockchainID *ids.ID         `json:"counterpartBlockchainID"`
+	FeeTokenAddress         *common.Address `json:"feeTokenAddress"`
+	FeeAmount               *big.Int        `json:"feeAmount"`
+	RelayerAddress          *common.Address `json:"relayerAddress"`
+}
+
+var historyCmd = &cobra.Command{
+	Use: "history --rpc RPC_URL --teleporter-address CONTRACT_ADDRESS " +
+		"--from-block FROM_BLOCK [--to-block TO_BLOCK] [--format csv|json|ndjson]",
+	Short: "Exports all Teleporter events in a block range",
+	Long: `Scans the given block range for logs of the Teleporter contract, paginating
+eth_getLogs requests, and writes one row per event with its block number, transaction
+hash, message ID, counterpart blockchain ID, fee, and relayer address. The counterpart
+is the destination chain of sent messages and receipts, and the source chain of
+received messages. The range is inclusive, and ends at the latest block if --to-block
+is not provided. Rows are written as csv, a json array, or newline delimited json.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		format, err := ToHistoryFormat(historyFormat)
+		cobra.CheckErr(err)
+		cobra.CheckErr(exportHistory(context.Background(), cmd.OutOrStdout(), format))
+		cmd.Println("History command ran successfully")
+	},
+}
+
+func exportHistory(ctx context.Context, w io.Writer, format HistoryFormat) error {
+	if historyPageSize == 0 {
+		return fmt.Errorf("--page-size must be positive")
+	}
+	toBlock := historyToBlock
+	if toBlock == 0 {
+		latest, err := client.BlockNumber(ctx)
+		if err != nil {
+			return err
+		}
+		toBlock = latest
+	}
+	if historyFromBlock > toBlock {
+		return fmt.Errorf("--from-block %d is after --to-block %d", historyFromBlock, toBlock)
+	}
+
+	writer, err := newHistoryWriter(w, format)
+	if err != nil {
+		return err
+	}
+	query := interfaces.FilterQuery{Addresses: []common.Address{teleporterAddress}}
+	err = filterLogsPaginated(ctx, client, query, historyFromBlock, toBlock, historyPageSize,
+		func(logs []types.Log) error {
+			for _, log := range logs {
+				row, err := newHistoryRow(log)
+				if err != nil {
+					return err
+				}
+				if err := writer.write(row); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+	return writer.close()
+}
+
+// logFilterer fetches the logs matching a query, such as an ethclient.Client
+type logFilterer interface {
+	FilterLogs(ctx context.Context, query interfaces.FilterQuery) ([]types.Log, error)
+}
+
+// Substrings of the errors returned by nodes that limit the number of blocks or logs per eth_getLogs request
+var logLimitErrors = []string{
+	"too many blocks",
+	"block range",
+	"query returned more than",
+	"limit exceeded",
+	"response size",
+}
+
+// filterLogsPaginated fetches the logs matching the query from fromBlock to toBlock inclusive, requesting at
+// most maxPageSize blocks at a time, and passes the logs of each page to handle in block order. The page size
+// is halved when the node rejects a request for covering too many blocks or returning too many logs, and grows
+// back towards maxPageSize after each successful request. Other errors are returned immediately.
+func filterLogsPaginated(
+	ctx context.Context,
+	filterer logFilterer,
+	query interfaces.FilterQuery,
+	fromBlock uint64,
+	toBlock uint64,
+	maxPageSize uint64,
+	handle func([]types.Log) error,
+) error {
+	pageSize := maxPageSize
+	for start := fromBlock; start <= toBlock; {
+		end := start + pageSize - 1
+		if end > toBlock || end < start {
+			end = toBlock
+		}
+		query.FromBlock = new(big.Int).SetUint64(start)
+		query.ToBlock = new(big.Int).SetUint64(end)
+		logs, err := filterer.FilterLogs(ctx, query)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if !isLogLimitError(err) || pageSize == 1 {
+				return err
+			}
+			pageSize /= 2
+			logger.Debug("Request exceeded the node's log limits, reducing page size",
+				zap.Uint64("pageSize", pageSize),
+				zap.Error(err))
+			continue
+		}
+		logger.Debug("Fetched logs",
+			zap.Uint64("fromBlock", start),
+			zap.Uint64("toBlock", end),
+			zap.Int("numLogs", len(logs)))
+		if err := handle(logs); err != nil {
+			return err
+		}
+		if end == toBlock {
+			return nil
+		}
+		start = end + 1
+		if pageSize < maxPageSize {
+			pageSize *= 2
+			if pageSize > maxPageSize {
+				pageSize = maxPageSize
+			}
+		}
+	}
+	return nil
+}
+
+func isLogLimitError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, s := range logLimitErrors {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// latestBlockFrom returns the latest block of the chain, to search for events from fromBlock up to it
+func latestBlockFrom(ctx context.Context, client ethclient.Client, fromBlock uint64) (uint64, error) {
+	height, err := client.BlockNumber(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if fromBlock > height {
+		return 0, fmt.Errorf("--from-block %d is after the latest block %d", fromBlock, height)
+	}
+	return height, nil
+}
+
+// filterTeleporterEvents returns the events emitted by the Teleporter contract between fromBlock and
+// toBlock inclusive that match the topics, in block order. The logs are fetched in pages so that
+// ranges exceeding the node's eth_getLogs limits can be searched.
+func filterTeleporterEvents(
+	ctx context.Context,
+	filterer logFilterer,
+	topics [][]common.Hash,
+	fromBlock uint64,
+	toBlock uint64,
+) ([]teleportermessenger.TeleporterEvent, error) {
+	query := interfaces.FilterQuery{
+		Addresses: []common.Address{teleporterAddress},
+		Topics:    topics,
+	}
+	var events []teleportermessenger.TeleporterEvent
+	err := filterLogsPaginated(ctx, filterer, query, fromBlock, toBlock, defaultHistoryPageSize,
+		func(logs []types.Log) error {
+			for _, log := range logs {
+				event, err := teleportermessenger.ParseTeleporterLog(log)
+				if err != nil {
+					return err
+				}
+				events = append(events, event)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// newHistoryRow decodes a Teleporter log into a row
+func newHistoryRow(log types.Log) (*historyRow, error) {
+	parsed, err := teleportermessenger.ParseTeleporterLog(log)
+	if err != nil {
+		return nil, err
+	}
+
+	row := &historyRow{
+		BlockNumber: log.BlockNumber,
+		TxHash:      log.TxHash,
+		LogIndex:    log.Index,
+		Event:       parsed.Event().String(),
+	}
+	switch e := parsed.(type) {
+	case *teleportermessenger.SendCrossChainMessageEvent:
+		row.setMessage(e.MessageID, e.DestinationBlockchainID)
+		row.setFee(e.FeeInfo.FeeTokenAddress, e.FeeInfo.Amount)
+	case *teleportermessenger.ReceiveCrossChainMessageEvent:
+		row.setMessage(e.MessageID, e.SourceBlockchainID)
+		row.RelayerAddress = &e.RewardRedeemer
+	case *teleportermessenger.AddFeeAmountEvent:
+		row.MessageID = (*ids.ID)(&e.MessageID)
+		row.setFee(e.UpdatedFeeInfo.FeeTokenAddress, e.UpdatedFeeInfo.Amount)
+	case *teleportermessenger.MessageExecutionFailedEvent:
+		row.setMessage(e.MessageID, e.SourceBlockchainID)
+	case *teleportermessenger.MessageExecutedEvent:
+		row.setMessage(e.MessageID, e.SourceBlockchainID)
+	case *teleportermessenger.RelayerRewardsRedeemedEvent:
+		row.setFee(e.Asset, e.Amount)
+		row.RelayerAddress = &e.Redeemer
+	case *teleportermessenger.ReceiptReceivedEvent:
+		row.setMessage(e.MessageID, e.DestinationBlockchainID)
+		row.setFee(e.FeeInfo.FeeTokenAddress, e.FeeInfo.Amount)
+		row.RelayerAddress = &e.RelayerRewardAddress
+	}
+	return row, nil
+}
+
+func (r *historyRow) setMessage(messageID [32]byte, counterpartBlockchainID [32]byte) {
+	r.MessageID = (*ids.ID)(&messageID)
+	r.CounterpartBlockchainID = (*ids.ID)(&counterpartBlockchainID)
+}
+
+func (r *historyRow) setFee(feeTokenAddress common.Address, amount *big.Int) {
+	r.FeeTokenAddress = &feeTokenAddress
+	r.FeeAmount = amount
+}
+
+// historyWriter writes rows in a history format. Csv and ndjson rows are written as they are
+// found, while json rows are written as a single array once all rows are found.
+type historyWriter struct {
+	w         io.Writer
+	format    HistoryFormat
+	csvWriter *csv.Writer
+	rows      []interface{}
+}
+
+func newHistoryWriter(w io.Writer, format HistoryFormat) (*historyWriter, error) {
+	writer := &historyWriter{
+		w:      w,
+		format: format,
+		rows:   []interface{}{},
+	}
+	if format == CSVHistory {
+		writer.csvWriter = csv.NewWriter(w)
+		var header []string
+		for _, f := range toDocument("", reflect.ValueOf(historyRow{})).(document) {
+			header = append(header, f.Key)
+		}
+		if err := writer.csvWriter.Write(header); err != nil {
+			return nil, err
+		}
+	}
+	return writer, nil
+}
+
+func (h *historyWriter) write(row *historyRow) error {
+	doc := toDocument("", reflect.ValueOf(row)).(document)
+	switch h.format {
+	case JSONHistory:
+		h.rows = append(h.rows, doc)
+		return nil
+	case NDJSONHistory:
+		b, err := json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(h.w, string(b))
+		return err
+	default:
+		record := make([]string, 0, len(doc))
+		for _, f := range doc {
+			if f.Value == nil {
+				record = append(record, "")
+			} else {
+				record = append(record, fmt.Sprint(f.Value))
+			}
+		}
+		return h.csvWriter.Write(record)
+	}
+}
+
+func (h *historyWriter) close() error {
+	switch h.format {
+	case JSONHistory:
+		encoder := json.NewEncoder(h.w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(h.rows)
+	case NDJSONHistory:
+		return nil
+	default:
+		h.csvWriter.Flush()
+		return h.csvWriter.Error()
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	addRPCFlags(historyCmd)
+	historyCmd.Flags().Uint64Var(&historyFromBlock, "from-block", 0, "First block of the range to export")
+	historyCmd.Flags().Uint64Var(&historyToBlock, "to-block", 0,
+		"Last block of the range to export, the latest block if not provided")
+	historyCmd.Flags().StringVar(&historyFormat, "format", csvHistoryStr, "Export format i.e. csv, json, ndjson")
+	historyCmd.Flags().Uint64Var(&historyPageSize, "page-size", defaultHistoryPageSize,
+		"Number of blocks requested in each eth_getLogs call, halved while the node rejects the range")
+	cobra.CheckErr(historyCmd.MarkFlagRequired("from-block"))
+}
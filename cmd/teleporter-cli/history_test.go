@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"history"},
+			err:  fmt.Errorf("required flag(s) \"from-block\", \"rpc\", \"teleporter-address\" not set"),
+		},
+		{
+			name: "help",
+			args: []string{"history", "--help"},
+			err:  nil,
+			out:  "Scans the given block range for logs of the Teleporter contract",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestToHistoryFormat(t *testing.T) {
+	var tests = []struct {
+		str     string
+		format  HistoryFormat
+		isError bool
+	}{
+		{"csv", CSVHistory, false},
+		{"JSON", JSONHistory, false},
+		{"ndjson", NDJSONHistory, false},
+		{"parquet", CSVHistory, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.str, func(t *testing.T) {
+			format, err := ToHistoryFormat(tt.str)
+			if tt.isError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.format, format)
+		})
+	}
+}
+
+func TestHistoryWriter(t *testing.T) {
+	sendRow := &historyRow{
+		BlockNumber: 10,
+		TxHash:      common.HexToHash("0x01"),
+		Event:       "SendCrossChainMessage",
+	}
+	sendRow.setMessage([32]byte{9}, ids.ID{1, 2, 3, 4})
+	sendRow.setFee(common.HexToAddress("0x02"), big.NewInt(100))
+	redeemRow := &historyRow{
+		BlockNumber: 11,
+		TxHash:      common.HexToHash("0x03"),
+		LogIndex:    1,
+		Event:       "RelayerRewardsRedeemed",
+	}
+	redeemRow.setFee(common.HexToAddress("0x02"), big.NewInt(50))
+	relayer := common.HexToAddress("0x04")
+	redeemRow.RelayerAddress = &relayer
+
+	// Rows are not annotated with resolved chains, so that every row has the same columns.
+	resolver = newChainResolver(&Config{
+		Networks: map[string]NetworkConfig{
+			"local": {
+				Chains: map[string]ChainConfig{
+					"a": {BlockchainID: ids.ID{1, 2, 3, 4}, EVMChainID: 5},
+				},
+			},
+		},
+	})
+	defer func() { resolver = nil }()
+	require.NotNil(t, resolver.annotate(ids.ID{1, 2, 3, 4}))
+
+	var tests = []struct {
+		format   HistoryFormat
+		expected []string
+	}{
+		{
+			format: CSVHistory,
+			expected: []string{
+				"blockNumber,txHash,logIndex,event,messageID,counterpartBlockchainID,feeTokenAddress,feeAmount,relayerAddress",
+				"10,0x0000000000000000000000000000000000000000000000000000000000000001,0,SendCrossChainMessage," +
+					"0x0900000000000000000000000000000000000000000000000000000000000000," +
+					ids.ID{1, 2, 3, 4}.String() + ",0x0000000000000000000000000000000000000002,100,",
+				"11,0x0000000000000000000000000000000000000000000000000000000000000003,1,RelayerRewardsRedeemed,,," +
+					"0x0000000000000000000000000000000000000002,50,0x0000000000000000000000000000000000000004",
+			},
+		},
+		{
+			format: NDJSONHistory,
+			expected: []string{
+				`{"blockNumber":10,"txHash":"0x0000000000000000000000000000000000000000000000000000000000000001",` +
+					`"logIndex":0,"event":"SendCrossChainMessage",` +
+					`"messageID":"0x0900000000000000000000000000000000000000000000000000000000000000",` +
+					`"counterpartBlockchainID":"` + ids.ID{1, 2, 3, 4}.String() + `",` +
+					`"feeTokenAddress":"0x0000000000000000000000000000000000000002","feeAmount":"100","relayerAddress":null}`,
+				`{"blockNumber":11,"txHash":"0x0000000000000000000000000000000000000000000000000000000000000003",` +
+					`"logIndex":1,"event":"RelayerRewardsRedeemed","messageID":null,"counterpartBlockchainID":null,` +
+					`"feeTokenAddress":"0x0000000000000000000000000000000000000002","feeAmount":"50",` +
+					`"relayerAddress":"0x0000000000000000000000000000000000000004"}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			writer, err := newHistoryWriter(&buf, tt.format)
+			require.NoError(t, err)
+			require.NoError(t, writer.write(sendRow))
+			require.NoError(t, writer.write(redeemRow))
+			require.NoError(t, writer.close())
+			require.Equal(t, tt.expected, strings.Split(strings.TrimSpace(buf.String()), "\n"))
+		})
+	}
+
+	t.Run(JSONHistory.String(), func(t *testing.T) {
+		var buf bytes.Buffer
+		writer, err := newHistoryWriter(&buf, JSONHistory)
+		require.NoError(t, err)
+		require.NoError(t, writer.close())
+		require.Equal(t, "[]", strings.TrimSpace(buf.String()))
+	})
+}
+
+// testLogFilterer returns one log per block, and rejects requests for more than maxBlocks blocks
+type testLogFilterer struct {
+	maxBlocks uint64
+	// Error returned by every request, if set
+	err      error
+	requests [][2]uint64
+}
+
+func (f *testLogFilterer) FilterLogs(ctx context.Context, query interfaces.FilterQuery) ([]types.Log, error) {
+	from, to := query.FromBlock.Uint64(), query.ToBlock.Uint64()
+	f.requests = append(f.requests, [2]uint64{from, to})
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	if to-from+1 > f.maxBlocks {
+		return nil, fmt.Errorf("requested too many blocks from %d to %d, maximum is set to %d", from, to, f.maxBlocks)
+	}
+	var logs []types.Log
+	for block := from; block <= to; block++ {
+		logs = append(logs, types.Log{BlockNumber: block})
+	}
+	return logs, nil
+}
+
+func TestFilterLogsPaginated(t *testing.T) {
+	logger = logging.NoLog{}
+
+	// Pages shrink to the node's limit, and grow back after successful requests.
+	filterer := &testLogFilterer{maxBlocks: 2}
+	var blocks []uint64
+	err := filterLogsPaginated(context.Background(), filterer, interfaces.FilterQuery{}, 1, 10, 4,
+		func(logs []types.Log) error {
+			for _, log := range logs {
+				blocks = append(blocks, log.BlockNumber)
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, blocks)
+	require.Equal(t, [][2]uint64{{1, 4}, {1, 2}, {3, 6}, {3, 4}, {5, 8}, {5, 6}, {7, 10}, {7, 8}, {9, 10}},
+		filterer.requests)
+
+	// Errors other than the node's limits are returned without retrying.
+	dialErr := errors.New("connection refused")
+	filterer = &testLogFilterer{maxBlocks: 4, err: dialErr}
+	err = filterLogsPaginated(context.Background(), filterer, interfaces.FilterQuery{}, 1, 10, 4,
+		func([]types.Log) error { return nil })
+	require.ErrorIs(t, err, dialErr)
+	require.Len(t, filterer.requests, 1)
+
+	// Cancelling the context stops the scan.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	filterer = &testLogFilterer{maxBlocks: 1}
+	err = filterLogsPaginated(ctx, filterer, interfaces.FilterQuery{}, 1, 10, 4,
+		func([]types.Log) error { return nil })
+	require.ErrorIs(t, err, context.Canceled)
+	require.Len(t, filterer.requests, 1)
+}
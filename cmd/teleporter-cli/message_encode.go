@@ -0,0 +1,179 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var (
+	encodeNonce                   string
+	encodeOriginSender            string
+	encodeDestinationBlockchainID string
+	encodeDestinationAddress      string
+	encodeRequiredGasLimit        string
+	encodeAllowedRelayers         []string
+	encodeReceipts                []string
+	encodePayload                 string
+	encodeNetworkID               uint32
+	encodeSourceBlockchainID      string
+	encodeSourceAddress           string
+)
+
+// encodedMessageOutput holds the encodings of a Teleporter message. The Warp
+// encodings are only set if the message was wrapped in a Warp message.
+type encodedMessageOutput struct {
+	TeleporterMessage []byte  `json:"teleporterMessage"`
+	AddressedCall     []byte  `json:"addressedCall,omitempty"`
+	UnsignedMessage   []byte  `json:"unsignedMessage,omitempty"`
+	UnsignedMessageID *ids.ID `json:"unsignedMessageID,omitempty"`
+}
+
+var messageEncodeCmd = &cobra.Command{
+	Use:   "encode --nonce NONCE --destination-blockchain-id BLOCKCHAIN_ID [flags]",
+	Short: "Encodes a TeleporterMessage struct into hex encoded Teleporter message bytes",
+	Long: `Given the fields of a TeleporterMessage, this command will ABI encode the
+message and print the resulting bytes as hex. Receipts are specified as
+NONCE:RELAYER_REWARD_ADDRESS pairs. If a source blockchain ID is provided, the
+message is additionally wrapped in a Warp AddressedCall payload and an unsigned
+Warp message for the given network ID.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		message, err := buildTeleporterMessage()
+		cobra.CheckErr(err)
+
+		messageBytes, err := teleportermessenger.PackTeleporterMessage(message)
+		cobra.CheckErr(err)
+		out := encodedMessageOutput{TeleporterMessage: messageBytes}
+
+		if encodeSourceBlockchainID != "" {
+			sourceBlockchainID, err := parseID(encodeSourceBlockchainID)
+			cobra.CheckErr(err)
+			sourceAddress, err := parseAddress(encodeSourceAddress)
+			cobra.CheckErr(err)
+
+			addressedCall, err := warpPayload.NewAddressedCall(sourceAddress.Bytes(), messageBytes)
+			cobra.CheckErr(err)
+			unsignedMessage, err := avalancheWarp.NewUnsignedMessage(
+				encodeNetworkID,
+				sourceBlockchainID,
+				addressedCall.Bytes(),
+			)
+			cobra.CheckErr(err)
+
+			unsignedMessageID := unsignedMessage.ID()
+			out.AddressedCall = addressedCall.Bytes()
+			out.UnsignedMessage = unsignedMessage.Bytes()
+			out.UnsignedMessageID = &unsignedMessageID
+		}
+		cobra.CheckErr(printOutput(cmd, out))
+		cmd.Println("Message encode command ran successfully")
+	},
+}
+
+func buildTeleporterMessage() (teleportermessenger.TeleporterMessage, error) {
+	var (
+		message teleportermessenger.TeleporterMessage
+		err     error
+	)
+	if message.MessageNonce, err = parseBigInt(encodeNonce); err != nil {
+		return message, err
+	}
+	if message.RequiredGasLimit, err = parseBigInt(encodeRequiredGasLimit); err != nil {
+		return message, err
+	}
+	if message.OriginSenderAddress, err = parseAddress(encodeOriginSender); err != nil {
+		return message, err
+	}
+	if message.DestinationAddress, err = parseAddress(encodeDestinationAddress); err != nil {
+		return message, err
+	}
+	destinationBlockchainID, err := parseID(encodeDestinationBlockchainID)
+	if err != nil {
+		return message, err
+	}
+	message.DestinationBlockchainID = destinationBlockchainID
+
+	message.AllowedRelayerAddresses = []common.Address{}
+	for _, relayer := range encodeAllowedRelayers {
+		address, err := parseAddress(relayer)
+		if err != nil {
+			return message, err
+		}
+		message.AllowedRelayerAddresses = append(message.AllowedRelayerAddresses, address)
+	}
+
+	message.Receipts = []teleportermessenger.TeleporterMessageReceipt{}
+	for _, receiptArg := range encodeReceipts {
+		receipt, err := parseReceipt(receiptArg)
+		if err != nil {
+			return message, err
+		}
+		message.Receipts = append(message.Receipts, receipt)
+	}
+
+	if message.Message, err = parseHexBytes(encodePayload); err != nil {
+		return message, err
+	}
+	return message, nil
+}
+
+// parseReceipt parses a receipt given as NONCE:RELAYER_REWARD_ADDRESS
+func parseReceipt(s string) (teleportermessenger.TeleporterMessageReceipt, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return teleportermessenger.TeleporterMessageReceipt{},
+			fmt.Errorf("invalid receipt %q, must be NONCE:RELAYER_REWARD_ADDRESS", s)
+	}
+	nonce, err := parseBigInt(parts[0])
+	if err != nil {
+		return teleportermessenger.TeleporterMessageReceipt{}, err
+	}
+	address, err := parseAddress(parts[1])
+	if err != nil {
+		return teleportermessenger.TeleporterMessageReceipt{}, err
+	}
+	return teleportermessenger.TeleporterMessageReceipt{
+		ReceivedMessageNonce: nonce,
+		RelayerRewardAddress: address,
+	}, nil
+}
+
+func init() {
+	messageCmd.AddCommand(messageEncodeCmd)
+	flags := messageEncodeCmd.Flags()
+	flags.StringVar(&encodeNonce, "nonce", "", "Message nonce")
+	flags.StringVar(&encodeOriginSender, "origin-sender", common.Address{}.Hex(),
+		"Address of the contract that sent the message")
+	flags.StringVar(&encodeDestinationBlockchainID, "destination-blockchain-id", "",
+		"Blockchain ID of the destination chain, in CB58 or hex")
+	flags.StringVar(&encodeDestinationAddress, "destination-address", common.Address{}.Hex(),
+		"Address of the contract receiving the message")
+	flags.StringVar(&encodeRequiredGasLimit, "required-gas-limit", "0",
+		"Gas limit required to execute the message")
+	flags.StringSliceVar(&encodeAllowedRelayers, "allowed-relayer", []string{},
+		"Addresses allowed to deliver the message")
+	flags.StringSliceVar(&encodeReceipts, "receipt", []string{},
+		"Receipts included in the message, as NONCE:RELAYER_REWARD_ADDRESS")
+	flags.StringVar(&encodePayload, "payload", "", "Hex encoded message payload")
+	flags.Uint32Var(&encodeNetworkID, "network-id", 0,
+		"Network ID of the Warp message, used with --source-blockchain-id")
+	flags.StringVar(&encodeSourceBlockchainID, "source-blockchain-id", "",
+		"If set, wraps the message in an unsigned Warp message sent from this blockchain ID")
+	flags.StringVar(&encodeSourceAddress, "source-address", common.Address{}.Hex(),
+		"Teleporter contract address used as the AddressedCall source address, used with --source-blockchain-id")
+
+	for _, flag := range []string{"nonce", "destination-blockchain-id"} {
+		err := messageEncodeCmd.MarkFlagRequired(flag)
+		cobra.CheckErr(err)
+	}
+}
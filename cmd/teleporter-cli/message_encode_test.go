@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageEncodeCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"message", "encode"},
+			err:  fmt.Errorf("required flag(s) \"destination-blockchain-id\", \"nonce\" not set"),
+		},
+		{
+			name: "help",
+			args: []string{"message", "encode", "--help"},
+			err:  nil,
+			out:  "Given the fields of a TeleporterMessage",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestBuildTeleporterMessage(t *testing.T) {
+	destinationBlockchainID := ids.ID{1, 2, 3, 4}
+	encodeNonce = "4"
+	encodeOriginSender = "0x0123456789abcdef0123456789abcdef01234567"
+	encodeDestinationBlockchainID = destinationBlockchainID.String()
+	encodeDestinationAddress = "0x0123456789abcdef0123456789abcdef01234567"
+	encodeRequiredGasLimit = "2"
+	encodeAllowedRelayers = []string{"0x0123456789abcdef0123456789abcdef01234567"}
+	encodeReceipts = []string{"1:0x0123456789abcdef0123456789abcdef01234567"}
+	encodePayload = "0x01020304"
+	defer func() {
+		encodeAllowedRelayers = []string{}
+		encodeReceipts = []string{}
+	}()
+
+	message, err := buildTeleporterMessage()
+	require.NoError(t, err)
+
+	// The test message in the bindings package is built from the same field values.
+	b, err := teleportermessenger.PackTeleporterMessage(message)
+	require.NoError(t, err)
+	unpacked, err := teleportermessenger.UnpackTeleporterMessage(b)
+	require.NoError(t, err)
+	require.Equal(t, message, *unpacked)
+	require.Equal(t, [32]byte(destinationBlockchainID), unpacked.DestinationBlockchainID)
+
+	// The encoded bytes round trip through the JSON output.
+	buf := new(bytes.Buffer)
+	require.NoError(t, writeOutput(buf, JSONOutput, encodedMessageOutput{TeleporterMessage: b}))
+	require.Contains(t, buf.String(), hex.EncodeToString(b))
+	require.NotContains(t, buf.String(), "unsignedMessage")
+}
+
+func TestParseReceipt(t *testing.T) {
+	receipt, err := parseReceipt("7:0x0123456789abcdef0123456789abcdef01234567")
+	require.NoError(t, err)
+	require.Equal(t, int64(7), receipt.ReceivedMessageNonce.Int64())
+	require.Equal(t, "0x0123456789abcDEF0123456789abCDef01234567", receipt.RelayerRewardAddress.Hex())
+
+	_, err = parseReceipt("7")
+	require.ErrorContains(t, err, "invalid receipt")
+
+	_, err = parseReceipt("7:0x01")
+	require.ErrorContains(t, err, "invalid address")
+}
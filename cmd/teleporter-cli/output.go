@@ -0,0 +1,326 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// OutputFormat is the format used to print command results
+type OutputFormat uint8
+
+const (
+	TableOutput OutputFormat = iota
+	JSONOutput
+	YAMLOutput
+
+	tableOutputStr = "table"
+	jsonOutputStr  = "json"
+	yamlOutputStr  = "yaml"
+)
+
+var (
+	bigIntType  = reflect.TypeOf(big.Int{})
+	addressType = reflect.TypeOf(common.Address{})
+	hashType    = reflect.TypeOf(common.Hash{})
+	logType     = reflect.TypeOf(types.Log{})
+
+	// Keys with these suffixes hold Avalanche IDs, which are printed in CB58.
+	// All other fixed size byte arrays are printed as hex.
+	cb58KeySuffixes = []string{"blockchainid", "subnetid"}
+)
+
+// String returns the string representation of an OutputFormat
+func (f OutputFormat) String() string {
+	switch f {
+	case JSONOutput:
+		return jsonOutputStr
+	case YAMLOutput:
+		return yamlOutputStr
+	default:
+		return tableOutputStr
+	}
+}
+
+// ToOutputFormat converts a string to an OutputFormat
+func ToOutputFormat(s string) (OutputFormat, error) {
+	switch strings.ToLower(s) {
+	case tableOutputStr:
+		return TableOutput, nil
+	case jsonOutputStr:
+		return JSONOutput, nil
+	case yamlOutputStr:
+		return YAMLOutput, nil
+	default:
+		return TableOutput, fmt.Errorf("unknown output format %s", s)
+	}
+}
+
+// field is a single key-value pair of a document. Documents are built from
+// ordered fields rather than maps so that output follows the struct field order.
+type field struct {
+	Key   string
+	Value interface{}
+}
+
+type document []field
+
+func (d document) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range d {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(f.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (d document) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, f := range d {
+		value := &yaml.Node{}
+		if err := value.Encode(f.Value); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: f.Key}, value)
+	}
+	return node, nil
+}
+
+// printOutput writes v to the command's output in the format selected by the --output flag.
+func printOutput(cmd *cobra.Command, v interface{}) error {
+	return writeOutput(cmd.OutOrStdout(), outputFormat, v)
+}
+
+func writeOutput(w io.Writer, format OutputFormat, v interface{}) error {
+	doc := toAnnotatedDocument("", reflect.ValueOf(v))
+	switch format {
+	case JSONOutput:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(doc)
+	case YAMLOutput:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(doc); err != nil {
+			return err
+		}
+		return encoder.Close()
+	default:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		writeTable(tw, "", doc)
+		return tw.Flush()
+	}
+}
+
+// toDocument converts v to a tree of documents, slices and strings. Byte slices and
+// arrays are hex encoded, big integers are printed in decimal, and Avalanche IDs keyed
+// by a blockchain or subnet ID are printed in CB58. The fields of the documents are
+// fixed by the type of v, so that rows of the same type have the same columns.
+func toDocument(key string, v reflect.Value) interface{} {
+	return buildDocument(key, v, false)
+}
+
+// toAnnotatedDocument converts v to a tree of documents like toDocument, and adds a
+// <key>Chain field after each blockchain ID field that the chain resolver can resolve.
+func toAnnotatedDocument(key string, v reflect.Value) interface{} {
+	return buildDocument(key, v, true)
+}
+
+func buildDocument(key string, v reflect.Value, annotate bool) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Type() {
+	case bigIntType:
+		b := v.Interface().(big.Int)
+		return b.String()
+	case addressType:
+		return v.Interface().(common.Address).Hex()
+	case hashType:
+		return v.Interface().(common.Hash).Hex()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		doc := document{}
+		for i := 0; i < v.NumField(); i++ {
+			structField := v.Type().Field(i)
+			if !structField.IsExported() {
+				continue
+			}
+			// Events decoded from topics and data alone have no raw log to show.
+			if structField.Type == logType && v.Field(i).IsZero() {
+				continue
+			}
+			name, options, _ := strings.Cut(structField.Tag.Get("json"), ",")
+			if name == "-" || (options == "omitempty" && v.Field(i).IsZero()) {
+				continue
+			}
+			if name == "" {
+				name = lowerCamelCase(structField.Name)
+			}
+			doc = append(doc, field{Key: name, Value: buildDocument(name, v.Field(i), annotate)})
+			if !annotate {
+				continue
+			}
+			if annotation := annotateBlockchainID(name, v.Field(i)); annotation != nil {
+				key := name + "Chain"
+				doc = append(doc, field{Key: key, Value: toDocument(key, reflect.ValueOf(annotation))})
+			}
+		}
+		return doc
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			if v.Len() == len(ids.ID{}) && hasCB58Key(key) {
+				return ids.ID(b).String()
+			}
+			return hexutil.Encode(b)
+		}
+		return toList(key, v, annotate)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return hexutil.Encode(v.Bytes())
+		}
+		return toList(key, v, annotate)
+	case reflect.Map:
+		// Sort map entries by key so that output is stable between runs.
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		doc := document{}
+		for _, k := range keys {
+			name := fmt.Sprint(k.Interface())
+			doc = append(doc, field{Key: name, Value: buildDocument(name, v.MapIndex(k), annotate)})
+		}
+		return doc
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+func toList(key string, v reflect.Value, annotate bool) []interface{} {
+	list := make([]interface{}, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		list = append(list, buildDocument(key, v.Index(i), annotate))
+	}
+	return list
+}
+
+func writeTable(w io.Writer, prefix string, v interface{}) {
+	switch t := v.(type) {
+	case document:
+		for _, f := range t {
+			key := f.Key
+			if prefix != "" {
+				key = prefix + "." + f.Key
+			}
+			writeTable(w, key, f.Value)
+		}
+	case []interface{}:
+		if len(t) == 0 {
+			fmt.Fprintf(w, "%s\t[]\n", prefix)
+		}
+		for i, elem := range t {
+			writeTable(w, fmt.Sprintf("%s[%d]", prefix, i), elem)
+		}
+	case nil:
+		fmt.Fprintf(w, "%s\t\n", prefix)
+	default:
+		fmt.Fprintf(w, "%s\t%v\n", prefix, t)
+	}
+}
+
+// annotateBlockchainID returns the chain that a blockchain ID field refers to, if the
+// chain resolver can resolve it
+func annotateBlockchainID(key string, v reflect.Value) *chainAnnotation {
+	if resolver == nil || !strings.HasSuffix(strings.ToLower(key), "blockchainid") {
+		return nil
+	}
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Array || v.Type().Elem().Kind() != reflect.Uint8 || v.Len() != len(ids.ID{}) {
+		return nil
+	}
+	var blockchainID ids.ID
+	reflect.Copy(reflect.ValueOf(blockchainID[:]), v)
+	return resolver.annotate(blockchainID)
+}
+
+func hasCB58Key(key string) bool {
+	key = strings.ToLower(key)
+	for _, suffix := range cb58KeySuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// lowerCamelCase converts an exported Go identifier such as EVMChainID to evmChainID
+func lowerCamelCase(s string) string {
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		if !unicode.IsUpper(runes[i]) {
+			break
+		}
+		// Keep the last upper case letter of an acronym if it starts the next word.
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToOutputFormat(t *testing.T) {
+	var tests = []struct {
+		str     string
+		format  OutputFormat
+		isError bool
+	}{
+		{"table", TableOutput, false},
+		{"JSON", JSONOutput, false},
+		{"yaml", YAMLOutput, false},
+		{"xml", TableOutput, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.str, func(t *testing.T) {
+			format, err := ToOutputFormat(tt.str)
+			if tt.isError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.format, format)
+		})
+	}
+}
+
+func TestWriteOutput(t *testing.T) {
+	blockchainID := ids.ID{1, 2, 3, 4}
+	event := eventOutput{
+		Name: "SendCrossChainMessage",
+		Event: &teleportermessenger.TeleporterMessengerSendCrossChainMessage{
+			MessageID:               [32]byte{9},
+			DestinationBlockchainID: blockchainID,
+			Message: teleportermessenger.TeleporterMessage{
+				MessageNonce:            big.NewInt(1),
+				RequiredGasLimit:        new(big.Int).Lsh(big.NewInt(1), 200),
+				AllowedRelayerAddresses: []common.Address{},
+				Message:                 []byte{0xca, 0xfe},
+			},
+			FeeInfo: teleportermessenger.TeleporterFeeInfo{Amount: big.NewInt(0)},
+		},
+	}
+
+	var tests = []struct {
+		format   OutputFormat
+		expected []string
+	}{
+		{
+			format: JSONOutput,
+			expected: []string{
+				`"name": "SendCrossChainMessage"`,
+				`"messageID": "0x0900000000000000000000000000000000000000000000000000000000000000"`,
+				`"destinationBlockchainID": "` + blockchainID.String() + `"`,
+				`"requiredGasLimit": "1606938044258990275541962092341162602522202993782792835301376"`,
+				`"message": "0xcafe"`,
+			},
+		},
+		{
+			format: YAMLOutput,
+			expected: []string{
+				"name: SendCrossChainMessage",
+				"destinationBlockchainID: " + blockchainID.String(),
+				`messageNonce: "1"`,
+			},
+		},
+		{
+			format: TableOutput,
+			expected: []string{
+				"event.destinationBlockchainID",
+				"event.message.allowedRelayerAddresses  []",
+				"event.message.message",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format.String(), func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			require.NoError(t, writeOutput(buf, tt.format, event))
+			for _, expected := range tt.expected {
+				require.Contains(t, buf.String(), expected)
+			}
+			require.NotContains(t, buf.String(), "raw")
+		})
+	}
+}
+
+func TestLowerCamelCase(t *testing.T) {
+	require.Equal(t, "messageID", lowerCamelCase("MessageID"))
+	require.Equal(t, "evmChainID", lowerCamelCase("EVMChainID"))
+	require.Equal(t, "id", lowerCamelCase("ID"))
+	require.Equal(t, "txHash", lowerCamelCase("TxHash"))
+}
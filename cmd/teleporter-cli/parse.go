@@ -0,0 +1,53 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// parseHexBytes decodes a hex string, with or without a leading 0x
+func parseHexBytes(s string) ([]byte, error) {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex string %q: %w", s, err)
+	}
+	return b, nil
+}
+
+// parseBigInt parses a decimal or 0x prefixed hex integer
+func parseBigInt(s string) (*big.Int, error) {
+	i, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %q", s)
+	}
+	return i, nil
+}
+
+// parseAddress parses a hex encoded EVM address
+func parseAddress(s string) (common.Address, error) {
+	if !common.IsHexAddress(s) {
+		return common.Address{}, fmt.Errorf("invalid address %q", s)
+	}
+	return common.HexToAddress(s), nil
+}
+
+// parseID parses an Avalanche ID, such as a blockchain ID, encoded either in CB58 or as 32 hex bytes
+func parseID(s string) (ids.ID, error) {
+	if id, err := ids.FromString(s); err == nil {
+		return id, nil
+	}
+	b, err := parseHexBytes(s)
+	if err != nil || len(b) != len(ids.ID{}) {
+		return ids.ID{}, fmt.Errorf("invalid ID %q, must be CB58 or 32 hex encoded bytes", s)
+	}
+	return ids.ID(b), nil
+}
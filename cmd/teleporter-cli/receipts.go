@@ -0,0 +1,260 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	teleporterutils "github.com/ava-labs/teleporter/utils/teleporter-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// The maximum number of receipts the Teleporter contract includes in a single message.
+const defaultReceiptBatchSize = 5
+
+var (
+	receiptsSourceBlockchainID string
+	receiptsBatchSize          int
+	receiptsFeeToken           string
+	receiptsFeeAmount          string
+)
+
+// receiptOutput is a receipt in the receipt queue, along with the ID of the message it is for
+type receiptOutput struct {
+	MessageID            ids.ID         `json:"messageID"`
+	ReceivedMessageNonce *big.Int       `json:"receivedMessageNonce"`
+	RelayerRewardAddress common.Address `json:"relayerRewardAddress"`
+}
+
+// receiptQueueOutput is the contents of the receipt queue for a source blockchain
+type receiptQueueOutput struct {
+	SourceBlockchainID ids.ID          `json:"sourceBlockchainID"`
+	Size               *big.Int        `json:"size"`
+	Receipts           []receiptOutput `json:"receipts"`
+}
+
+// receiptBatchOutput is a sendSpecifiedReceipts transaction sending a batch of receipts
+type receiptBatchOutput struct {
+	TxHash     common.Hash `json:"txHash"`
+	MessageID  ids.ID      `json:"messageID"`
+	MessageIDs []ids.ID    `json:"receiptMessageIDs"`
+}
+
+// receiptFlushOutput is the result of sending the receipts in the receipt queue for a source blockchain
+type receiptFlushOutput struct {
+	SourceBlockchainID ids.ID               `json:"sourceBlockchainID"`
+	Batches            []receiptBatchOutput `json:"batches"`
+}
+
+var receiptsCmd = &cobra.Command{
+	Use:   "receipts",
+	Short: "Commands for inspecting and sending queued Teleporter receipts",
+	Long: `Commands for inspecting and sending the receipts queued by a Teleporter contract for
+messages it received from a source blockchain. Queued receipts are normally only sent
+back with the next message to the source blockchain, so on chains where traffic is
+one-directional they can remain queued indefinitely.`,
+	Args: cobra.NoArgs,
+}
+
+var receiptsListCmd = &cobra.Command{
+	Use: "list --dest-rpc DEST_RPC_URL --teleporter-address CONTRACT_ADDRESS " +
+		"--source-blockchain-id BLOCKCHAIN_ID",
+	Short: "Lists the receipts queued for a source blockchain",
+	Long: `Given the blockchain ID of a source chain, this command reads the receipt queue for
+that chain from the Teleporter contract on the chain that received its messages using
+getReceiptQueueSize and getReceiptAtIndex, and lists each queued receipt along with
+the ID of the message it is for.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		sourceBlockchainID, err := parseID(receiptsSourceBlockchainID)
+		cobra.CheckErr(err)
+
+		out, err := listReceipts(context.Background(), sourceBlockchainID)
+		cobra.CheckErr(err)
+		cobra.CheckErr(printOutput(cmd, out))
+		cmd.Println("Receipts list command ran successfully")
+	},
+}
+
+var receiptsFlushCmd = &cobra.Command{
+	Use: "flush --dest-rpc DEST_RPC_URL --teleporter-address CONTRACT_ADDRESS " +
+		"--source-blockchain-id BLOCKCHAIN_ID --key-file KEY_FILE",
+	Short: "Sends the receipts queued for a source blockchain",
+	Long: `Given the blockchain ID of a source chain, this command sends the receipts queued for
+that chain back to it by calling sendSpecifiedReceipts in batches of --batch-size,
+without sending any other message. Each batch is sent as its own Teleporter message,
+which may be incentivized with --fee-token and --fee-amount.
+
+Note that sendSpecifiedReceipts does not remove receipts from the queue. They are
+still included in later messages to the source chain, where they are ignored since
+they have already been received.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		sourceBlockchainID, err := parseID(receiptsSourceBlockchainID)
+		cobra.CheckErr(err)
+
+		out, err := flushReceipts(context.Background(), sourceBlockchainID)
+		cobra.CheckErr(err)
+		cobra.CheckErr(printOutput(cmd, out))
+		cmd.Println("Receipts flush command ran successfully")
+	},
+}
+
+func listReceipts(ctx context.Context, sourceBlockchainID ids.ID) (*receiptQueueOutput, error) {
+	messenger, err := teleportermessenger.NewTeleporterMessenger(teleporterAddress, destClient)
+	if err != nil {
+		return nil, err
+	}
+	opts := &bind.CallOpts{Context: ctx}
+
+	size, err := messenger.GetReceiptQueueSize(opts, sourceBlockchainID)
+	if err != nil {
+		return nil, err
+	}
+	out := &receiptQueueOutput{
+		SourceBlockchainID: sourceBlockchainID,
+		Size:               size,
+		Receipts:           []receiptOutput{},
+	}
+	if size.Sign() == 0 {
+		return out, nil
+	}
+
+	// The receipts are for messages sent from the source blockchain to this blockchain.
+	blockchainID, err := messenger.BlockchainID(opts)
+	if err != nil {
+		return nil, err
+	}
+	for i := int64(0); i < size.Int64(); i++ {
+		receipt, err := messenger.GetReceiptAtIndex(opts, sourceBlockchainID, big.NewInt(i))
+		if err != nil {
+			return nil, err
+		}
+		messageID, err := teleporterutils.CalculateMessageID(
+			teleporterAddress,
+			sourceBlockchainID,
+			blockchainID,
+			receipt.ReceivedMessageNonce,
+		)
+		if err != nil {
+			return nil, err
+		}
+		out.Receipts = append(out.Receipts, receiptOutput{
+			MessageID:            messageID,
+			ReceivedMessageNonce: receipt.ReceivedMessageNonce,
+			RelayerRewardAddress: receipt.RelayerRewardAddress,
+		})
+	}
+	return out, nil
+}
+
+func flushReceipts(ctx context.Context, sourceBlockchainID ids.ID) (*receiptFlushOutput, error) {
+	if receiptsBatchSize <= 0 {
+		return nil, errors.New("--batch-size must be positive")
+	}
+	feeInfo, err := receiptsFeeInfo()
+	if err != nil {
+		return nil, err
+	}
+	signer, err := newSigner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	queue, err := listReceipts(ctx, sourceBlockchainID)
+	if err != nil {
+		return nil, err
+	}
+	out := &receiptFlushOutput{
+		SourceBlockchainID: sourceBlockchainID,
+		Batches:            []receiptBatchOutput{},
+	}
+	if len(queue.Receipts) == 0 {
+		logger.Info("Receipt queue is empty", zap.Stringer("sourceBlockchainID", sourceBlockchainID))
+		return out, nil
+	}
+
+	dest, err := newTeleporterClient(ctx, destClient, signer)
+	if err != nil {
+		return nil, err
+	}
+	for _, batch := range receiptBatches(queue.Receipts, receiptsBatchSize) {
+		batchOut := receiptBatchOutput{MessageIDs: make([]ids.ID, 0, len(batch))}
+		for _, receipt := range batch {
+			batchOut.MessageIDs = append(batchOut.MessageIDs, receipt.MessageID)
+		}
+		messageID, receipt, err := dest.SendSpecifiedReceipts(ctx, sourceBlockchainID, batchOut.MessageIDs, feeInfo, nil)
+		if err != nil {
+			return nil, err
+		}
+		batchOut.TxHash = receipt.TxHash
+		batchOut.MessageID = messageID
+		logger.Info("Sent receipts",
+			zap.Stringer("sourceBlockchainID", sourceBlockchainID),
+			zap.Int("numReceipts", len(batch)),
+			zap.String("txHash", receipt.TxHash.Hex()))
+		out.Batches = append(out.Batches, batchOut)
+	}
+	return out, nil
+}
+
+// receiptsFeeInfo returns the fee paid for each batch of receipts, which is zero unless a fee token is provided
+func receiptsFeeInfo() (teleportermessenger.TeleporterFeeInfo, error) {
+	feeInfo := teleportermessenger.TeleporterFeeInfo{Amount: big.NewInt(0)}
+	if receiptsFeeToken == "" {
+		if receiptsFeeAmount != "" {
+			return feeInfo, errors.New("--fee-amount requires --fee-token")
+		}
+		return feeInfo, nil
+	}
+	feeToken, err := parseAddress(receiptsFeeToken)
+	if err != nil {
+		return feeInfo, err
+	}
+	feeInfo.FeeTokenAddress = feeToken
+	if receiptsFeeAmount != "" {
+		if feeInfo.Amount, err = parseBigInt(receiptsFeeAmount); err != nil {
+			return feeInfo, err
+		}
+	}
+	return feeInfo, nil
+}
+
+// receiptBatches splits the receipts into consecutive batches of at most batchSize receipts
+func receiptBatches(receipts []receiptOutput, batchSize int) [][]receiptOutput {
+	var batches [][]receiptOutput
+	for start := 0; start < len(receipts); start += batchSize {
+		end := start + batchSize
+		if end > len(receipts) {
+			end = len(receipts)
+		}
+		batches = append(batches, receipts[start:end])
+	}
+	return batches
+}
+
+func init() {
+	rootCmd.AddCommand(receiptsCmd)
+	receiptsCmd.AddCommand(receiptsListCmd)
+	receiptsCmd.AddCommand(receiptsFlushCmd)
+	for _, cmd := range []*cobra.Command{receiptsListCmd, receiptsFlushCmd} {
+		addChainFlags(cmd, destinationChainLabel)
+		cmd.Flags().StringVar(&receiptsSourceBlockchainID, "source-blockchain-id", "",
+			"Blockchain ID of the chain the queued receipts are sent to")
+		cobra.CheckErr(cmd.MarkFlagRequired("source-blockchain-id"))
+	}
+	addSignerFlags(receiptsFlushCmd, true)
+	receiptsFlushCmd.Flags().IntVar(&receiptsBatchSize, "batch-size", defaultReceiptBatchSize,
+		"Maximum number of receipts sent in each sendSpecifiedReceipts transaction")
+	receiptsFlushCmd.Flags().StringVar(&receiptsFeeToken, "fee-token", "",
+		"Address of the ERC20 token used to pay relayers for delivering each batch")
+	receiptsFlushCmd.Flags().StringVar(&receiptsFeeAmount, "fee-amount", "",
+		"Amount of the fee token paid for each batch")
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceiptsCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "list no args",
+			args: []string{"receipts", "list"},
+			err:  fmt.Errorf("required flag(s) \"dest-rpc\", \"source-blockchain-id\", \"teleporter-address\" not set"),
+		},
+		{
+			name: "flush no args",
+			args: []string{"receipts", "flush"},
+			err: fmt.Errorf(
+				"required flag(s) \"dest-rpc\", \"source-blockchain-id\", \"teleporter-address\" not set"),
+		},
+		{
+			name: "list help",
+			args: []string{"receipts", "list", "--help"},
+			err:  nil,
+			out:  "getReceiptQueueSize and getReceiptAtIndex",
+		},
+		{
+			name: "flush help",
+			args: []string{"receipts", "flush", "--help"},
+			err:  nil,
+			out:  "calling sendSpecifiedReceipts in batches",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestReceiptBatches(t *testing.T) {
+	receipts := make([]receiptOutput, 7)
+	for i := range receipts {
+		receipts[i].ReceivedMessageNonce = big.NewInt(int64(i + 1))
+	}
+
+	var tests = []struct {
+		name      string
+		receipts  []receiptOutput
+		batchSize int
+		expected  []int
+	}{
+		{
+			name:      "empty",
+			receipts:  nil,
+			batchSize: 5,
+			expected:  nil,
+		},
+		{
+			name:      "partial last batch",
+			receipts:  receipts,
+			batchSize: 5,
+			expected:  []int{5, 2},
+		},
+		{
+			name:      "exact batches",
+			receipts:  receipts[:6],
+			batchSize: 3,
+			expected:  []int{3, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batches := receiptBatches(tt.receipts, tt.batchSize)
+			require.Len(t, batches, len(tt.expected))
+			var nonce int64
+			for i, batch := range batches {
+				require.Len(t, batch, tt.expected[i])
+				for _, receipt := range batch {
+					nonce++
+					require.Equal(t, nonce, receipt.ReceivedMessageNonce.Int64())
+				}
+			}
+		})
+	}
+}
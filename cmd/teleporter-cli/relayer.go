@@ -0,0 +1,255 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	rewardsRelayerAddress string
+	rewardsFeeTokens      []string
+	rewardsRedeem         bool
+	rewardsFromBlock      uint64
+)
+
+// relayerRewardsOutput is the redeemable reward balance of a relayer for each fee token
+type relayerRewardsOutput struct {
+	Relayer common.Address        `json:"relayer"`
+	Rewards []relayerRewardOutput `json:"rewards"`
+}
+
+// relayerRewardOutput is the reward balance of a relayer for a single fee token. The redemption
+// fields are only set if the rewards were redeemed.
+type relayerRewardOutput struct {
+	FeeToken       common.Address `json:"feeToken"`
+	Amount         *big.Int       `json:"amount"`
+	RedeemedAmount *big.Int       `json:"redeemedAmount,omitempty"`
+	RedeemTxHash   common.Hash    `json:"redeemTxHash,omitempty"`
+}
+
+var relayerCmd = &cobra.Command{
+	Use:   "relayer",
+	Short: "Commands for relayer operators",
+	Long:  `Commands for relayer operators to inspect and manage their Teleporter relayer rewards.`,
+	Args:  cobra.NoArgs,
+}
+
+var relayerRewardsCmd = &cobra.Command{
+	Use: "rewards --source-rpc SOURCE_RPC_URL --teleporter-address CONTRACT_ADDRESS " +
+		"(--relayer-address ADDRESS | --key-file KEY_FILE) [--fee-token TOKEN_ADDRESS]...",
+	Short: "Reports and optionally redeems the rewards earned by a relayer",
+	Long: `Given a relayer reward address, this command calls checkRelayerRewardAmount on the
+Teleporter contract of the chain that sent the delivered messages for each of the
+given fee tokens. If no fee tokens are given, they are discovered by scanning the
+ReceiptReceived events that credited the relayer, from --from-block to the latest
+block. With --redeem, redeemRelayerRewards
+is called for each fee token with a non-zero balance, signed by the
+relayer's signer, and the amounts from the RelayerRewardsRedeemed events are reported.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		out, err := relayerRewards(context.Background())
+		cobra.CheckErr(err)
+		cobra.CheckErr(printOutput(cmd, out))
+		cmd.Println("Relayer rewards command ran successfully")
+	},
+}
+
+func relayerRewards(ctx context.Context) (*relayerRewardsOutput, error) {
+	if rewardsRedeem && !signerOpts.isSet() {
+		return nil, fmt.Errorf("%w to redeem rewards", errNoSigner)
+	}
+	out := &relayerRewardsOutput{Rewards: []relayerRewardOutput{}}
+	if signerOpts.isSet() {
+		signer, err := newSigner(ctx)
+		if err != nil {
+			return nil, err
+		}
+		out.Relayer = signer.Address()
+	}
+	if rewardsRelayerAddress != "" {
+		relayer, err := parseAddress(rewardsRelayerAddress)
+		if err != nil {
+			return nil, err
+		}
+		if signerOpts.isSet() && relayer != out.Relayer {
+			return nil, errors.New("--relayer-address does not match the address of the signer")
+		}
+		out.Relayer = relayer
+	}
+	if out.Relayer == (common.Address{}) {
+		return nil, errors.New("either --relayer-address or a signer must be provided")
+	}
+
+	messenger, err := teleportermessenger.NewTeleporterMessenger(teleporterAddress, sourceClient)
+	if err != nil {
+		return nil, err
+	}
+	feeTokens, err := rewardFeeTokens(ctx, out.Relayer)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, feeToken := range feeTokens {
+		amount, err := messenger.CheckRelayerRewardAmount(&bind.CallOpts{Context: ctx}, out.Relayer, feeToken)
+		if err != nil {
+			return nil, err
+		}
+		reward := relayerRewardOutput{
+			FeeToken: feeToken,
+			Amount:   amount,
+		}
+		if rewardsRedeem && amount.Sign() > 0 {
+			if err := redeemRelayerRewards(ctx, messenger, &reward); err != nil {
+				return nil, err
+			}
+		}
+		out.Rewards = append(out.Rewards, reward)
+	}
+	return out, nil
+}
+
+// rewardFeeTokens returns the fee tokens given on the command line, or otherwise the fee tokens
+// discovered from the receipts that credited the relayer since --from-block.
+func rewardFeeTokens(
+	ctx context.Context,
+	relayer common.Address,
+) ([]common.Address, error) {
+	if len(rewardsFeeTokens) > 0 {
+		feeTokens := make([]common.Address, 0, len(rewardsFeeTokens))
+		for _, s := range rewardsFeeTokens {
+			feeToken, err := parseAddress(s)
+			if err != nil {
+				return nil, err
+			}
+			feeTokens = append(feeTokens, feeToken)
+		}
+		return feeTokens, nil
+	}
+
+	height, err := sourceClient.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if rewardsFromBlock > height {
+		return nil, fmt.Errorf("--from-block %d is after the latest block %d", rewardsFromBlock, height)
+	}
+	feeTokens, err := discoverRewardFeeTokens(ctx, sourceClient, relayer, rewardsFromBlock, height)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debug("Discovered relayer fee tokens",
+		zap.String("relayer", relayer.Hex()),
+		zap.Int("numFeeTokens", len(feeTokens)),
+		zap.Uint64("fromBlock", rewardsFromBlock))
+	return feeTokens, nil
+}
+
+// discoverRewardFeeTokens returns the distinct fee tokens of the receipts that credited the relayer
+// between fromBlock and toBlock inclusive, sorted by address
+func discoverRewardFeeTokens(
+	ctx context.Context,
+	filterer logFilterer,
+	relayer common.Address,
+	fromBlock uint64,
+	toBlock uint64,
+) ([]common.Address, error) {
+	query := interfaces.FilterQuery{
+		Addresses: []common.Address{teleporterAddress},
+		Topics: [][]common.Hash{
+			{teleporterABI.Events["ReceiptReceived"].ID},
+			nil,
+			nil,
+			{common.BytesToHash(relayer.Bytes())},
+		},
+	}
+	seen := make(map[common.Address]struct{})
+	err := filterLogsPaginated(ctx, filterer, query, fromBlock, toBlock, defaultHistoryPageSize,
+		func(logs []types.Log) error {
+			for _, log := range logs {
+				parsed, err := teleportermessenger.ParseTeleporterLog(log)
+				if err != nil {
+					return err
+				}
+				receipt, ok := parsed.(*teleportermessenger.ReceiptReceivedEvent)
+				if !ok || receipt.RelayerRewardAddress != relayer {
+					continue
+				}
+				// Messages sent without a fee do not earn rewards.
+				feeToken := receipt.FeeInfo.FeeTokenAddress
+				if feeToken == (common.Address{}) || receipt.FeeInfo.Amount.Sign() == 0 {
+					continue
+				}
+				seen[feeToken] = struct{}{}
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	feeTokens := make([]common.Address, 0, len(seen))
+	for feeToken := range seen {
+		feeTokens = append(feeTokens, feeToken)
+	}
+	sort.Slice(feeTokens, func(i, j int) bool {
+		return bytes.Compare(feeTokens[i][:], feeTokens[j][:]) < 0
+	})
+	return feeTokens, nil
+}
+
+func redeemRelayerRewards(
+	ctx context.Context,
+	messenger *teleportermessenger.TeleporterMessenger,
+	reward *relayerRewardOutput,
+) error {
+	signer, err := newSigner(ctx)
+	if err != nil {
+		return err
+	}
+	source, err := newTeleporterClient(ctx, sourceClient, signer)
+	if err != nil {
+		return err
+	}
+	receipt, err := source.RedeemRewards(ctx, reward.FeeToken)
+	if err != nil {
+		return err
+	}
+	reward.RedeemTxHash = receipt.TxHash
+	for _, log := range receipt.Logs {
+		if event, err := messenger.ParseRelayerRewardsRedeemed(*log); err == nil && event.Asset == reward.FeeToken {
+			reward.RedeemedAmount = event.Amount
+		}
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(relayerCmd)
+	relayerCmd.AddCommand(relayerRewardsCmd)
+	addChainFlags(relayerRewardsCmd, sourceChainLabel)
+	relayerRewardsCmd.Flags().StringVar(&rewardsRelayerAddress, "relayer-address", "",
+		"Reward address of the relayer, the address of the signer if not provided")
+	relayerRewardsCmd.Flags().StringSliceVar(&rewardsFeeTokens, "fee-token", nil,
+		"Address of a fee token to check, may be repeated. Discovered from ReceiptReceived events if not provided")
+	relayerRewardsCmd.Flags().BoolVar(&rewardsRedeem, "redeem", false,
+		"Redeem the rewards for each fee token with a non-zero balance")
+	addSignerFlags(relayerRewardsCmd, false)
+	relayerRewardsCmd.Flags().Uint64Var(&rewardsFromBlock, "from-block", 0,
+		"First block to search for ReceiptReceived events when discovering fee tokens, such as the block "+
+			"the Teleporter contract was deployed in")
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayerRewardsCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"relayer", "rewards"},
+			err:  fmt.Errorf("required flag(s) \"source-rpc\", \"teleporter-address\" not set"),
+		},
+		{
+			name: "unexpected args",
+			args: []string{"relayer", "rewards", "extra"},
+			err:  fmt.Errorf("unknown command \"extra\" for \"teleporter-cli relayer rewards\""),
+		},
+		{
+			name: "help",
+			args: []string{"relayer", "rewards", "--help"},
+			err:  nil,
+			out:  "Given a relayer reward address, this command calls checkRelayerRewardAmount",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+// blockLogFilterer returns the logs in the requested block range that match the topics of the query
+type blockLogFilterer struct {
+	logs []types.Log
+}
+
+func (f *blockLogFilterer) FilterLogs(_ context.Context, query interfaces.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	for _, log := range f.logs {
+		if log.BlockNumber >= query.FromBlock.Uint64() && log.BlockNumber <= query.ToBlock.Uint64() &&
+			matchesTopics(log, query.Topics) {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+func matchesTopics(log types.Log, topics [][]common.Hash) bool {
+	for i, options := range topics {
+		if len(options) == 0 {
+			continue
+		}
+		if i >= len(log.Topics) {
+			return false
+		}
+		matched := false
+		for _, topic := range options {
+			matched = matched || log.Topics[i] == topic
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDiscoverRewardFeeTokens(t *testing.T) {
+	logger = logging.NoLog{}
+	abi, err := teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	require.NoError(t, err)
+	teleporterABI = abi
+
+	relayer := common.HexToAddress("0x01")
+	otherRelayer := common.HexToAddress("0x02")
+	tokenA := common.HexToAddress("0x0a")
+	tokenB := common.HexToAddress("0x0b")
+	tokenC := common.HexToAddress("0x0c")
+	receipt := func(block uint64, relayer common.Address, feeToken common.Address, amount int64) types.Log {
+		topics, data, err := teleporterABI.PackEvent("ReceiptReceived", ids.GenerateTestID(), ids.GenerateTestID(),
+			relayer, teleportermessenger.TeleporterFeeInfo{FeeTokenAddress: feeToken, Amount: big.NewInt(amount)})
+		require.NoError(t, err)
+		return types.Log{Topics: topics, Data: data, BlockNumber: block}
+	}
+	filterer := &blockLogFilterer{logs: []types.Log{
+		// Credited long before the latest block, spanning several pages
+		receipt(10, relayer, tokenB, 5),
+		receipt(3*defaultHistoryPageSize, relayer, tokenA, 1),
+		receipt(3*defaultHistoryPageSize, relayer, tokenB, 2),
+		// Messages without a fee, and receipts crediting other relayers, are skipped
+		receipt(4*defaultHistoryPageSize, relayer, tokenC, 0),
+		receipt(4*defaultHistoryPageSize, otherRelayer, tokenC, 3),
+	}}
+
+	feeTokens, err := discoverRewardFeeTokens(context.Background(), filterer, relayer, 0, 5*defaultHistoryPageSize)
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{tokenA, tokenB}, feeTokens)
+
+	feeTokens, err = discoverRewardFeeTokens(context.Background(), filterer, relayer, 11, 5*defaultHistoryPageSize)
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{tokenA, tokenB}, feeTokens)
+
+	feeTokens, err = discoverRewardFeeTokens(context.Background(), filterer, relayer, 0, 100)
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{tokenB}, feeTokens)
+}
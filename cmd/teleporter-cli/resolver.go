@@ -0,0 +1,213 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"go.uber.org/zap"
+)
+
+const (
+	cChainAlias     = "C"
+	cChainName      = "c-chain"
+	nodeLookupLimit = 30 * time.Second
+)
+
+// The chain resolver used to annotate blockchain IDs in command output, if a configuration file is loaded
+var resolver *ChainResolver
+
+// chainAnnotation identifies the chain a blockchain ID belongs to
+type chainAnnotation struct {
+	Name       string `json:"name"`
+	EVMChainID uint64 `json:"evmChainID,omitempty"`
+	SubnetID   ids.ID `json:"subnetID"`
+}
+
+// ChainResolver maps blockchain IDs to configured chains. Chains that are not configured are
+// only known to the resolver after discover has looked them up from the node of their network.
+type ChainResolver struct {
+	chains map[ids.ID]*Chain
+	// Node URLs of the networks that chains can be discovered from, by network name
+	nodeURLs map[string]string
+}
+
+// newChainResolver creates a resolver for the chains in the configuration file
+func newChainResolver(config *Config) *ChainResolver {
+	r := &ChainResolver{
+		chains:   make(map[ids.ID]*Chain),
+		nodeURLs: make(map[string]string),
+	}
+	for network, networkConfig := range config.Networks {
+		for name, chain := range networkConfig.Chains {
+			r.add(&Chain{Network: network, Name: name, ChainConfig: chain})
+		}
+		if networkConfig.NodeURL != "" {
+			r.nodeURLs[network] = networkConfig.NodeURL
+		}
+	}
+	return r
+}
+
+func (r *ChainResolver) add(chain *Chain) {
+	if chain.BlockchainID == ids.Empty {
+		return
+	}
+	// Configured chains take precedence over chains looked up from a node.
+	if _, ok := r.chains[chain.BlockchainID]; !ok {
+		r.chains[chain.BlockchainID] = chain
+	}
+}
+
+// discover looks up the chains of each network with a node URL from its P-Chain, allowing
+// nodeLookupLimit for each network. The networks that could not be looked up are returned
+// as an error, after the chains of the other networks have been added.
+func (r *ChainResolver) discover(ctx context.Context) error {
+	networks := make([]string, 0, len(r.nodeURLs))
+	for network := range r.nodeURLs {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+	var failed []string
+	for _, network := range networks {
+		nodeURL := r.nodeURLs[network]
+		lookupCtx, cancel := context.WithTimeout(ctx, nodeLookupLimit)
+		chains, err := discoverChains(lookupCtx, nodeURL, network, nil)
+		cancel()
+		if err != nil {
+			logger.Warn("Failed to look up chains from node",
+				zap.String("network", network),
+				zap.String("nodeURL", nodeURL),
+				zap.Error(err))
+			failed = append(failed, network)
+			continue
+		}
+		for _, chain := range chains {
+			r.add(chain)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to discover the chains of networks %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// Resolve returns the chain with the given blockchain ID. It does not make any network calls.
+func (r *ChainResolver) Resolve(blockchainID ids.ID) (*Chain, bool) {
+	if r == nil || blockchainID == ids.Empty {
+		return nil, false
+	}
+	chain, ok := r.chains[blockchainID]
+	return chain, ok
+}
+
+// annotate returns the annotation for the given blockchain ID, or nil if it can not be resolved
+func (r *ChainResolver) annotate(blockchainID ids.ID) *chainAnnotation {
+	chain, ok := r.Resolve(blockchainID)
+	if !ok {
+		return nil
+	}
+	return &chainAnnotation{
+		Name:       chain.String(),
+		EVMChainID: chain.EVMChainID,
+		SubnetID:   chain.SubnetID,
+	}
+}
+
+// discoverChains queries the P-Chain of the node at nodeURL for its blockchains, optionally
+// restricted to the given subnets. The C-Chain is identified with info.getBlockchainID. For
+// each chain that serves the EVM JSON-RPC API on the node, the EVM chain ID and endpoints are
+// also set.
+func discoverChains(ctx context.Context, nodeURL string, network string, subnetIDs []ids.ID) ([]*Chain, error) {
+	nodeURL = strings.TrimSuffix(nodeURL, "/")
+	cChainID, err := info.NewClient(nodeURL).GetBlockchainID(ctx, cChainAlias)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get C-Chain blockchain ID: %w", err)
+	}
+	blockchains, err := platformvm.NewClient(nodeURL).GetBlockchains(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blockchains: %w", err)
+	}
+
+	subnets := make(map[ids.ID]struct{}, len(subnetIDs))
+	for _, subnetID := range subnetIDs {
+		subnets[subnetID] = struct{}{}
+	}
+	names := make(map[string]struct{})
+	var chains []*Chain
+	for _, blockchain := range blockchains {
+		if _, ok := subnets[blockchain.SubnetID]; len(subnets) > 0 && !ok {
+			continue
+		}
+		name := chainName(blockchain.Name)
+		if blockchain.ID == cChainID {
+			name = cChainName
+		}
+		// Chain names on the P-Chain are not unique.
+		if _, ok := names[name]; ok {
+			name = name + "-" + blockchain.ID.String()[:8]
+		}
+		names[name] = struct{}{}
+
+		chain := &Chain{
+			Network: network,
+			Name:    name,
+			ChainConfig: ChainConfig{
+				BlockchainID: blockchain.ID,
+				SubnetID:     blockchain.SubnetID,
+			},
+		}
+		if err := setEVMEndpoints(ctx, nodeURL, chain); err != nil {
+			return nil, err
+		}
+		chains = append(chains, chain)
+	}
+	return chains, nil
+}
+
+// setEVMEndpoints sets the EVM chain ID and endpoints of a chain if the node serves
+// the EVM JSON-RPC API for it. An error is only returned if the context is done, so that
+// chains are not left without endpoints because the lookup ran out of time.
+func setEVMEndpoints(ctx context.Context, nodeURL string, chain *Chain) error {
+	rpcURL := fmt.Sprintf("%s/ext/bc/%s/rpc", nodeURL, chain.BlockchainID)
+	evmChainID, err := func() (*big.Int, error) {
+		c, err := ethclient.DialContext(ctx, rpcURL)
+		if err != nil {
+			return nil, err
+		}
+		defer c.Close()
+		return c.ChainID(ctx)
+	}()
+	if err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("failed to get the EVM chain ID of %s: %w", chain.BlockchainID, ctx.Err())
+		}
+		logger.Debug("Chain does not serve the EVM JSON-RPC API",
+			zap.Stringer("blockchainID", chain.BlockchainID),
+			zap.Error(err))
+		return nil
+	}
+	chain.EVMChainID = evmChainID.Uint64()
+	chain.RPCURL = rpcURL
+	wsURL := strings.Replace(nodeURL, "http", "ws", 1)
+	chain.WSURL = fmt.Sprintf("%s/ext/bc/%s/ws", wsURL, chain.BlockchainID)
+	return nil
+}
+
+// chainName converts a P-Chain blockchain name into a configuration file chain name
+func chainName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return r == ' ' || r == '_' || r == '/'
+	}), "-")
+}
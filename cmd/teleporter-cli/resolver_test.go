@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainResolver(t *testing.T) {
+	blockchainID := ids.GenerateTestID()
+	subnetID := ids.GenerateTestID()
+	r := newChainResolver(&Config{
+		Networks: map[string]NetworkConfig{
+			"local": {
+				Chains: map[string]ChainConfig{
+					"subnet-a": {BlockchainID: blockchainID, SubnetID: subnetID, EVMChainID: 68430},
+					"subnet-b": {},
+				},
+			},
+		},
+	})
+
+	chain, ok := r.Resolve(blockchainID)
+	require.True(t, ok)
+	require.Equal(t, "local/subnet-a", chain.String())
+
+	_, ok = r.Resolve(ids.GenerateTestID())
+	require.False(t, ok)
+	_, ok = r.Resolve(ids.Empty)
+	require.False(t, ok)
+
+	require.Equal(t, &chainAnnotation{
+		Name:       "local/subnet-a",
+		EVMChainID: 68430,
+		SubnetID:   subnetID,
+	}, r.annotate(blockchainID))
+	require.Nil(t, r.annotate(ids.GenerateTestID()))
+
+	var nilResolver *ChainResolver
+	_, ok = nilResolver.Resolve(blockchainID)
+	require.False(t, ok)
+}
+
+func TestChainResolverDiscover(t *testing.T) {
+	logger = logging.NoLog{}
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	r := newChainResolver(&Config{
+		Networks: map[string]NetworkConfig{
+			"local": {NodeURL: server.URL},
+		},
+	})
+
+	// Unknown blockchain IDs are not looked up when they are resolved.
+	_, ok := r.Resolve(ids.GenerateTestID())
+	require.False(t, ok)
+	require.Zero(t, requests.Load())
+
+	err := r.discover(context.Background())
+	require.ErrorContains(t, err, "failed to discover the chains of networks local")
+	require.NotZero(t, requests.Load())
+}
+
+func TestChainName(t *testing.T) {
+	var tests = []struct {
+		name     string
+		expected string
+	}{
+		{"dispatch", "dispatch"},
+		{"Echo Subnet", "echo-subnet"},
+		{" my_chain/v2 ", "my-chain-v2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, chainName(tt.name))
+		})
+	}
+}
+
+func TestWriteOutputAnnotations(t *testing.T) {
+	blockchainID := ids.GenerateTestID()
+	resolver = newChainResolver(&Config{
+		Networks: map[string]NetworkConfig{
+			"fuji": {
+				Chains: map[string]ChainConfig{
+					"dispatch": {BlockchainID: blockchainID, EVMChainID: 779672},
+				},
+			},
+		},
+	})
+	defer func() { resolver = nil }()
+
+	out := struct {
+		DestinationBlockchainID ids.ID `json:"destinationBlockchainID"`
+		SourceBlockchainID      ids.ID `json:"sourceBlockchainID"`
+	}{
+		DestinationBlockchainID: blockchainID,
+		SourceBlockchainID:      ids.GenerateTestID(),
+	}
+	var buf bytes.Buffer
+	require.NoError(t, writeOutput(&buf, JSONOutput, out))
+	require.Contains(t, buf.String(), `"destinationBlockchainIDChain": {`)
+	require.Contains(t, buf.String(), `"name": "fuji/dispatch"`)
+	require.Contains(t, buf.String(), `"evmChainID": 779672`)
+	require.NotContains(t, buf.String(), "sourceBlockchainIDChain")
+}
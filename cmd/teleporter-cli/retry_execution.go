@@ -0,0 +1,169 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	teleporterclient "github.com/ava-labs/teleporter/pkg/client"
+	teleporterutils "github.com/ava-labs/teleporter/utils/teleporter-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	retryExecutionDryRun    bool
+	retryExecutionGasLimit  uint64
+	retryExecutionFromBlock uint64
+)
+
+// retryExecutionOutput is the result of retrying the execution of a Teleporter message
+type retryExecutionOutput struct {
+	MessageID          ids.ID      `json:"messageID"`
+	SourceBlockchainID ids.ID      `json:"sourceBlockchainID"`
+	DryRun             bool        `json:"dryRun"`
+	TxHash             common.Hash `json:"txHash,omitempty"`
+	Executed           bool        `json:"executed"`
+}
+
+var retryExecutionCmd = &cobra.Command{
+	Use:   "retry-execution --dest-rpc DEST_RPC_URL --teleporter-address CONTRACT_ADDRESS --key-file KEY_FILE MESSAGE_ID",
+	Short: "Retries the execution of a Teleporter message whose execution failed",
+	Long: `Given the ID of a Teleporter message whose execution failed on the destination
+chain, this command locates the MessageExecutionFailed event for the message by
+searching the destination chain from --from-block, reconstructs the message from
+the event, and calls retryMessageExecution on the destination Teleporter
+contract. The reconstructed message is checked against the failed message hash
+stored by the contract before it is retried. With --dry-run, the retry is
+simulated with eth_call instead of being submitted, and no signer is required.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		messageID, err := parseID(args[0])
+		cobra.CheckErr(err)
+
+		out, err := retryMessageExecution(context.Background(), messageID)
+		cobra.CheckErr(err)
+		cobra.CheckErr(printOutput(cmd, out))
+		cmd.Println("Retry execution command ran successfully")
+	},
+}
+
+func retryMessageExecution(ctx context.Context, messageID ids.ID) (*retryExecutionOutput, error) {
+	if !retryExecutionDryRun && !signerOpts.isSet() {
+		return nil, fmt.Errorf("%w unless --dry-run is set", errNoSigner)
+	}
+	var signer teleporterclient.Signer
+	if signerOpts.isSet() {
+		var err error
+		if signer, err = newSigner(ctx); err != nil {
+			return nil, err
+		}
+	}
+	dest, err := newTeleporterClient(ctx, destClient, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	height, err := latestBlockFrom(ctx, destClient, retryExecutionFromBlock)
+	if err != nil {
+		return nil, err
+	}
+	failedEvent, err := findMessageExecutionFailed(ctx, destClient, messageID, retryExecutionFromBlock, height)
+	if err != nil {
+		return nil, err
+	}
+	out := &retryExecutionOutput{
+		MessageID:          messageID,
+		SourceBlockchainID: failedEvent.SourceBlockchainID,
+		DryRun:             retryExecutionDryRun,
+	}
+
+	// The failed message hash is cleared once the message is successfully executed.
+	if err := dest.VerifyFailedMessage(ctx, failedEvent.SourceBlockchainID, failedEvent.Message); err != nil {
+		if errors.Is(err, teleporterutils.ErrMessageHashNotFound) {
+			return nil, errors.New("message has no failed execution to retry")
+		}
+		return nil, fmt.Errorf("reconstructed message cannot be retried: %w", err)
+	}
+
+	if retryExecutionDryRun {
+		data, err := teleportermessenger.PackRetryMessageExecution(failedEvent.SourceBlockchainID, failedEvent.Message)
+		if err != nil {
+			return nil, err
+		}
+		var from common.Address
+		if signer != nil {
+			from = signer.Address()
+		}
+		if _, err := callContract(ctx, destClient, from, teleporterAddress, data); err != nil {
+			return nil, err
+		}
+		out.Executed = true
+		return out, nil
+	}
+
+	receipt, err := dest.RetryExecution(ctx, failedEvent.SourceBlockchainID, failedEvent.Message,
+		teleporterclient.WithGasLimit(retryExecutionGasLimit))
+	if err != nil {
+		return nil, err
+	}
+	out.TxHash = receipt.TxHash
+	for _, log := range receipt.Logs {
+		if event, err := dest.Messenger().ParseMessageExecuted(*log); err == nil && event.MessageID == messageID {
+			out.Executed = true
+		}
+	}
+	return out, nil
+}
+
+// findMessageExecutionFailed returns the most recent MessageExecutionFailed event for the given message
+// between fromBlock and toBlock inclusive
+func findMessageExecutionFailed(
+	ctx context.Context,
+	filterer logFilterer,
+	messageID ids.ID,
+	fromBlock uint64,
+	toBlock uint64,
+) (*teleportermessenger.MessageExecutionFailedEvent, error) {
+	events, err := filterTeleporterEvents(ctx, filterer, [][]common.Hash{
+		{teleporterABI.Events["MessageExecutionFailed"].ID},
+		{common.Hash(messageID)},
+	}, fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	var event *teleportermessenger.MessageExecutionFailedEvent
+	for _, e := range events {
+		if failedEvent, ok := e.(*teleportermessenger.MessageExecutionFailedEvent); ok &&
+			failedEvent.MessageID == messageID {
+			event = failedEvent
+		}
+	}
+	if event == nil {
+		return nil, errors.New("no MessageExecutionFailed event found for message, try decreasing --from-block")
+	}
+	logger.Debug("Found MessageExecutionFailed event",
+		zap.Stringer("messageID", messageID),
+		zap.Uint64("blockNumber", event.Raw.BlockNumber),
+		zap.String("txHash", event.Raw.TxHash.Hex()))
+	return event, nil
+}
+
+func init() {
+	rootCmd.AddCommand(retryExecutionCmd)
+	addChainFlags(retryExecutionCmd, destinationChainLabel)
+	addSignerFlags(retryExecutionCmd, false)
+	retryExecutionCmd.Flags().BoolVar(&retryExecutionDryRun, "dry-run", false,
+		"Simulate the retry with eth_call instead of submitting a transaction")
+	retryExecutionCmd.Flags().Uint64Var(&retryExecutionGasLimit, "gas-limit", 0,
+		"Gas limit of the retry transaction, estimated if not provided")
+	retryExecutionCmd.Flags().Uint64Var(&retryExecutionFromBlock, "from-block", 0,
+		"First block on the destination chain to search for the failed execution")
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/subnet-evm/core/types"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryExecutionCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"retry-execution"},
+			err:  fmt.Errorf("accepts 1 arg(s), received 0"),
+		},
+		{
+			name: "help",
+			args: []string{"retry-execution", "--help"},
+			err:  nil,
+			out:  "Given the ID of a Teleporter message whose execution failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestLoadPrivateKey(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	keyHex := fmt.Sprintf("%x", crypto.FromECDSA(key))
+
+	var tests = []struct {
+		name     string
+		contents string
+		err      bool
+	}{
+		{
+			name:     "hex",
+			contents: keyHex,
+		},
+		{
+			name:     "prefixed hex with newline",
+			contents: "0x" + keyHex + "\n",
+		},
+		{
+			name:     "invalid",
+			contents: "not a key",
+			err:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyFile := filepath.Join(t.TempDir(), "key")
+			require.NoError(t, os.WriteFile(keyFile, []byte(tt.contents), 0o600))
+
+			loaded, err := loadPrivateKey(keyFile)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, key.D, loaded.D)
+		})
+	}
+}
+
+func TestFindMessageExecutionFailed(t *testing.T) {
+	logger = logging.NoLog{}
+	abi, err := teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	require.NoError(t, err)
+	teleporterABI = abi
+
+	messageID := ids.GenerateTestID()
+	sourceBlockchainID := ids.GenerateTestID()
+	failed := func(block uint64, messageID ids.ID, nonce int64) types.Log {
+		message := teleportermessenger.TeleporterMessage{
+			MessageNonce:            big.NewInt(nonce),
+			RequiredGasLimit:        big.NewInt(1),
+			AllowedRelayerAddresses: []common.Address{},
+			Receipts:                []teleportermessenger.TeleporterMessageReceipt{},
+			Message:                 []byte{},
+		}
+		topics, data, err := teleporterABI.PackEvent("MessageExecutionFailed", messageID, sourceBlockchainID, message)
+		require.NoError(t, err)
+		return types.Log{Topics: topics, Data: data, BlockNumber: block}
+	}
+	// The execution failed many pages before the latest block, and was retried and failed again later
+	filterer := &blockLogFilterer{logs: []types.Log{
+		failed(10, messageID, 1),
+		failed(2*defaultHistoryPageSize, ids.GenerateTestID(), 2),
+		failed(3*defaultHistoryPageSize, messageID, 1),
+	}}
+
+	event, err := findMessageExecutionFailed(context.Background(), filterer, messageID, 0, 5*defaultHistoryPageSize)
+	require.NoError(t, err)
+	require.Equal(t, messageID, ids.ID(event.MessageID))
+	require.Equal(t, sourceBlockchainID, ids.ID(event.SourceBlockchainID))
+	require.Equal(t, uint64(3*defaultHistoryPageSize), event.Raw.BlockNumber)
+
+	_, err = findMessageExecutionFailed(context.Background(), filterer, messageID, 0, 9)
+	require.ErrorContains(t, err, "try decreasing --from-block")
+}
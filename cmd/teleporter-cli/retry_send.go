@@ -0,0 +1,139 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	teleporterclient "github.com/ava-labs/teleporter/pkg/client"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	retrySendGasLimit  uint64
+	retrySendFromBlock uint64
+)
+
+// retrySendOutput is the result of resending a Teleporter message
+type retrySendOutput struct {
+	MessageID               ids.ID      `json:"messageID"`
+	DestinationBlockchainID ids.ID      `json:"destinationBlockchainID"`
+	MessageHash             common.Hash `json:"messageHash"`
+	TxHash                  common.Hash `json:"txHash"`
+}
+
+var retrySendCmd = &cobra.Command{
+	Use: "retry-send --source-rpc SOURCE_RPC_URL --teleporter-address CONTRACT_ADDRESS " +
+		"--key-file KEY_FILE MESSAGE_ID",
+	Short: "Resends a Teleporter message that has not yet been delivered",
+	Long: `Given the ID of a Teleporter message sent from the source chain, this command
+locates the SendCrossChainMessage event for the message by searching the source
+chain from --from-block, reconstructs the original message from the event, and
+calls retrySendCrossChainMessage on the source Teleporter contract so that
+relayers pick up the message again. The reconstructed message is checked against
+the message hash stored by the contract before it is resent.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		messageID, err := parseID(args[0])
+		cobra.CheckErr(err)
+
+		out, err := retrySendMessage(context.Background(), messageID)
+		cobra.CheckErr(err)
+		cobra.CheckErr(printOutput(cmd, out))
+		cmd.Println("Retry send command ran successfully")
+	},
+}
+
+func retrySendMessage(ctx context.Context, messageID ids.ID) (*retrySendOutput, error) {
+	signer, err := newSigner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	source, err := newTeleporterClient(ctx, sourceClient, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	// The message hash is cleared once the receipt for the message is returned.
+	messageHash, err := source.Messenger().GetMessageHash(&bind.CallOpts{Context: ctx}, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if messageHash == (common.Hash{}) {
+		return nil, errors.New("message not found, or its receipt has already been returned")
+	}
+
+	height, err := latestBlockFrom(ctx, sourceClient, retrySendFromBlock)
+	if err != nil {
+		return nil, err
+	}
+	sendEvent, err := findSendCrossChainMessage(ctx, sourceClient, messageID, retrySendFromBlock, height)
+	if err != nil {
+		return nil, err
+	}
+	if err := source.VerifySentMessage(ctx, sendEvent.Message); err != nil {
+		return nil, fmt.Errorf("reconstructed message cannot be resent: %w", err)
+	}
+
+	receipt, err := source.RetrySend(ctx, sendEvent.Message, teleporterclient.WithGasLimit(retrySendGasLimit))
+	if err != nil {
+		return nil, err
+	}
+	return &retrySendOutput{
+		MessageID:               messageID,
+		DestinationBlockchainID: sendEvent.DestinationBlockchainID,
+		MessageHash:             messageHash,
+		TxHash:                  receipt.TxHash,
+	}, nil
+}
+
+// findSendCrossChainMessage returns the most recent SendCrossChainMessage event for the given message
+// between fromBlock and toBlock inclusive
+func findSendCrossChainMessage(
+	ctx context.Context,
+	filterer logFilterer,
+	messageID ids.ID,
+	fromBlock uint64,
+	toBlock uint64,
+) (*teleportermessenger.SendCrossChainMessageEvent, error) {
+	events, err := filterTeleporterEvents(ctx, filterer, [][]common.Hash{
+		{teleporterABI.Events["SendCrossChainMessage"].ID},
+		{common.Hash(messageID)},
+	}, fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	var event *teleportermessenger.SendCrossChainMessageEvent
+	for _, e := range events {
+		if sendEvent, ok := e.(*teleportermessenger.SendCrossChainMessageEvent); ok && sendEvent.MessageID == messageID {
+			event = sendEvent
+		}
+	}
+	if event == nil {
+		return nil, errors.New("no SendCrossChainMessage event found for message, try decreasing --from-block")
+	}
+	logger.Debug("Found SendCrossChainMessage event",
+		zap.Stringer("messageID", messageID),
+		zap.Uint64("blockNumber", event.Raw.BlockNumber),
+		zap.String("txHash", event.Raw.TxHash.Hex()))
+	return event, nil
+}
+
+func init() {
+	rootCmd.AddCommand(retrySendCmd)
+	addChainFlags(retrySendCmd, sourceChainLabel)
+	addSignerFlags(retrySendCmd, true)
+	retrySendCmd.Flags().Uint64Var(&retrySendGasLimit, "gas-limit", 0,
+		"Gas limit of the retry transaction, estimated if not provided")
+	retrySendCmd.Flags().Uint64Var(&retrySendFromBlock, "from-block", 0,
+		"First block on the source chain to search for the sent message")
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/subnet-evm/core/types"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetrySendCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"retry-send"},
+			err:  fmt.Errorf("accepts 1 arg(s), received 0"),
+		},
+		{
+			name: "help",
+			args: []string{"retry-send", "--help"},
+			err:  nil,
+			out:  "locates the SendCrossChainMessage event for the message",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestFindSendCrossChainMessage(t *testing.T) {
+	logger = logging.NoLog{}
+	abi, err := teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	require.NoError(t, err)
+	teleporterABI = abi
+
+	messageID := ids.GenerateTestID()
+	otherMessageID := ids.GenerateTestID()
+	destinationBlockchainID := ids.GenerateTestID()
+	send := func(block uint64, messageID ids.ID, nonce int64) types.Log {
+		message := teleportermessenger.TeleporterMessage{
+			MessageNonce:            big.NewInt(nonce),
+			DestinationBlockchainID: destinationBlockchainID,
+			RequiredGasLimit:        big.NewInt(1),
+			AllowedRelayerAddresses: []common.Address{},
+			Receipts:                []teleportermessenger.TeleporterMessageReceipt{},
+			Message:                 []byte{},
+		}
+		feeInfo := teleportermessenger.TeleporterFeeInfo{Amount: big.NewInt(0)}
+		topics, data, err := teleporterABI.PackEvent("SendCrossChainMessage", messageID, destinationBlockchainID,
+			message, feeInfo)
+		require.NoError(t, err)
+		return types.Log{Topics: topics, Data: data, BlockNumber: block}
+	}
+	// The message was sent many pages before the latest block, and other messages were sent since
+	filterer := &blockLogFilterer{logs: []types.Log{
+		send(10, messageID, 1),
+		send(3*defaultHistoryPageSize, otherMessageID, 2),
+	}}
+
+	event, err := findSendCrossChainMessage(context.Background(), filterer, messageID, 0, 5*defaultHistoryPageSize)
+	require.NoError(t, err)
+	require.Equal(t, messageID, ids.ID(event.MessageID))
+	require.Equal(t, big.NewInt(1), event.Message.MessageNonce)
+	require.Equal(t, uint64(10), event.Raw.BlockNumber)
+
+	_, err = findSendCrossChainMessage(context.Background(), filterer, messageID, 11, 5*defaultHistoryPageSize)
+	require.ErrorContains(t, err, "try decreasing --from-block")
+}
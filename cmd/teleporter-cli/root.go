@@ -4,6 +4,7 @@
 package main
 
 import (
+	"context"
 	"os"
 
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -15,6 +16,7 @@ import (
 var (
 	logger        logging.Logger
 	teleporterABI *abi.ABI
+	outputFormat  OutputFormat
 )
 
 var rootCmd = &cobra.Command{
@@ -37,12 +39,25 @@ func Execute() {
 func init() {
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 	logLevelArg := rootCmd.PersistentFlags().StringP("log", "l", "", "Log level i.e. debug, info...")
+	outputArg := rootCmd.PersistentFlags().StringP("output", "o", tableOutputStr, "Output format i.e. table, json, yaml")
+	configArg := rootCmd.PersistentFlags().String(configFlag, "",
+		"Path of the configuration file, ~/.teleporter/config.yaml if not provided")
+	chainArg := rootCmd.PersistentFlags().String(chainFlag, "",
+		"Name of the configured chain to use, as NETWORK/CHAIN or CHAIN")
+	discoverArg := rootCmd.PersistentFlags().Bool(discoverChainsFlag, false,
+		"Look up the chains of each configured network with a nodeURL before running the command")
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
-		return rootPreRunE(logLevelArg)
+		return rootPreRunE(logLevelArg, outputArg, configArg, chainArg, discoverArg)
 	}
 }
 
-func rootPreRunE(logLevelArg *string) error {
+func rootPreRunE(
+	logLevelArg *string,
+	outputArg *string,
+	configArg *string,
+	chainArg *string,
+	discoverArg *bool,
+) error {
 	if *logLevelArg == "" {
 		*logLevelArg = logging.Info.LowerString()
 	}
@@ -51,11 +66,22 @@ func rootPreRunE(logLevelArg *string) error {
 	if err != nil {
 		return err
 	}
+	format, err := ToOutputFormat(*outputArg)
+	if err != nil {
+		return err
+	}
+	outputFormat = format
+
+	// Keep stdout free of log lines when printing machine-readable output.
+	logWriter := os.Stdout
+	if outputFormat != TableOutput {
+		logWriter = os.Stderr
+	}
 	logger = logging.NewLogger(
 		"teleporter-cli",
 		logging.NewWrappedCore(
 			logLevel,
-			os.Stdout,
+			logWriter,
 			logging.Plain.ConsoleEncoder(),
 		),
 	)
@@ -64,11 +90,31 @@ func rootPreRunE(logLevelArg *string) error {
 		return err
 	}
 	teleporterABI = abi
+
+	config, err := loadConfig(*configArg)
+	if err != nil {
+		return err
+	}
+	cliConfig = config
+	resolver = newChainResolver(cliConfig)
+	if *discoverArg {
+		// Chains are only looked up here, so that printing output never waits on a node.
+		if err := resolver.discover(context.Background()); err != nil {
+			return err
+		}
+	}
+	selectedChain = nil
+	if *chainArg != "" {
+		if selectedChain, err = cliConfig.Chain(*chainArg); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 func callPersistentPreRunE(cmd *cobra.Command, args []string) error {
-	if parent := cmd.Parent(); parent != nil {
+	// Cobra only runs the closest persistent pre-run function, so run the next one up the command tree.
+	for parent := cmd.Parent(); parent != nil; parent = parent.Parent() {
 		if parent.PersistentPreRunE != nil {
 			return parent.PersistentPreRunE(parent, args)
 		}
@@ -0,0 +1,205 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	teleporterclient "github.com/ava-labs/teleporter/pkg/client"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const defaultSendRequiredGasLimit = 100_000
+
+var (
+	sendDestinationChain        string
+	sendDestinationBlockchainID string
+	sendDestinationAddress      string
+	sendPayload                 string
+	sendRequiredGasLimit        uint64
+	sendFeeToken                string
+	sendFeeAmount               string
+	sendAllowedRelayers         []string
+	sendGasLimit                uint64
+)
+
+// sendOutput is the result of sending a Teleporter message
+type sendOutput struct {
+	MessageID               ids.ID                                `json:"messageID"`
+	DestinationBlockchainID ids.ID                                `json:"destinationBlockchainID"`
+	MessageNonce            *big.Int                              `json:"messageNonce"`
+	ApprovalTxHash          common.Hash                           `json:"approvalTxHash,omitempty"`
+	TxHash                  common.Hash                           `json:"txHash"`
+	BlockNumber             uint64                                `json:"blockNumber"`
+	FeeInfo                 teleportermessenger.TeleporterFeeInfo `json:"feeInfo"`
+}
+
+var sendCmd = &cobra.Command{
+	Use: "send --source-rpc SOURCE_RPC_URL --teleporter-address CONTRACT_ADDRESS " +
+		"--destination-chain CHAIN | --destination-blockchain-id BLOCKCHAIN_ID " +
+		"--destination-address ADDRESS --key-file KEY_FILE [flags]",
+	Short: "Sends a Teleporter message",
+	Long: `Sends a Teleporter message from the source chain by calling sendCrossChainMessage
+on the source Teleporter contract, waits for the transaction to be accepted, and
+prints the ID of the message from its SendCrossChainMessage event. The destination
+is either a chain in the configuration file, given with --destination-chain, or a
+blockchain ID. The payload is the hex encoded message passed to the destination
+address. If a relayer fee is given and the Teleporter contract's allowance to
+spend the fee token on behalf of the sender is below the fee, an approval
+transaction is sent first.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		input, err := buildMessageInput()
+		cobra.CheckErr(err)
+
+		out, err := sendCrossChainMessage(context.Background(), input)
+		cobra.CheckErr(err)
+		cobra.CheckErr(printOutput(cmd, out))
+		cmd.Println("Send command ran successfully")
+	},
+}
+
+// buildMessageInput builds the input of sendCrossChainMessage from the command's flags
+func buildMessageInput() (teleportermessenger.TeleporterMessageInput, error) {
+	var input teleportermessenger.TeleporterMessageInput
+	destinationBlockchainID, err := sendDestination()
+	if err != nil {
+		return input, err
+	}
+	destinationAddress, err := parseAddress(sendDestinationAddress)
+	if err != nil {
+		return input, err
+	}
+	payload, err := parseHexBytes(sendPayload)
+	if err != nil {
+		return input, err
+	}
+	feeAmount, err := parseBigInt(sendFeeAmount)
+	if err != nil {
+		return input, err
+	}
+	if feeAmount.Sign() < 0 {
+		return input, errors.New("fee amount must not be negative")
+	}
+	var feeToken common.Address
+	if sendFeeToken != "" {
+		if feeToken, err = parseAddress(sendFeeToken); err != nil {
+			return input, err
+		}
+	}
+	if feeAmount.Sign() > 0 && feeToken == (common.Address{}) {
+		return input, errors.New("--fee-token is required if --fee-amount is positive")
+	}
+	allowedRelayers := make([]common.Address, 0, len(sendAllowedRelayers))
+	for _, s := range sendAllowedRelayers {
+		relayer, err := parseAddress(s)
+		if err != nil {
+			return input, err
+		}
+		allowedRelayers = append(allowedRelayers, relayer)
+	}
+
+	return teleportermessenger.TeleporterMessageInput{
+		DestinationBlockchainID: destinationBlockchainID,
+		DestinationAddress:      destinationAddress,
+		FeeInfo: teleportermessenger.TeleporterFeeInfo{
+			FeeTokenAddress: feeToken,
+			Amount:          feeAmount,
+		},
+		RequiredGasLimit:        new(big.Int).SetUint64(sendRequiredGasLimit),
+		AllowedRelayerAddresses: allowedRelayers,
+		Message:                 payload,
+	}, nil
+}
+
+// sendDestination returns the destination blockchain ID given by --destination-blockchain-id, or of
+// the configured chain given by --destination-chain
+func sendDestination() (ids.ID, error) {
+	if sendDestinationBlockchainID != "" {
+		return parseID(sendDestinationBlockchainID)
+	}
+	chain, err := cliConfig.Chain(sendDestinationChain)
+	if err != nil {
+		return ids.ID{}, err
+	}
+	if chain.BlockchainID == ids.Empty {
+		return ids.ID{}, fmt.Errorf("chain %s has no blockchainID in the config file", chain)
+	}
+	return chain.BlockchainID, nil
+}
+
+func sendCrossChainMessage(
+	ctx context.Context,
+	input teleportermessenger.TeleporterMessageInput,
+) (*sendOutput, error) {
+	signer, err := newSigner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	source, err := newTeleporterClient(ctx, sourceClient, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &sendOutput{DestinationBlockchainID: input.DestinationBlockchainID}
+	approval, err := source.EnsureAllowance(ctx, input.FeeInfo.FeeTokenAddress, input.FeeInfo.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if approval != nil {
+		out.ApprovalTxHash = approval.TxHash
+	}
+
+	messageID, receipt, err := source.Send(ctx, input, teleporterclient.WithGasLimit(sendGasLimit))
+	if err != nil {
+		return nil, err
+	}
+	out.MessageID = messageID
+	out.TxHash = receipt.TxHash
+	out.BlockNumber = receipt.BlockNumber.Uint64()
+	for _, log := range receipt.Logs {
+		if log.Address != teleporterAddress {
+			continue
+		}
+		if event, err := source.Messenger().ParseSendCrossChainMessage(*log); err == nil {
+			out.MessageNonce = event.Message.MessageNonce
+			out.FeeInfo = event.FeeInfo
+		}
+	}
+	logger.Debug("Sent Teleporter message",
+		zap.Stringer("messageID", out.MessageID),
+		zap.Stringer("destinationBlockchainID", out.DestinationBlockchainID))
+	return out, nil
+}
+
+func init() {
+	rootCmd.AddCommand(sendCmd)
+	addChainFlags(sendCmd, sourceChainLabel)
+	sendCmd.Flags().StringVar(&sendDestinationChain, destinationChainFlag, "",
+		"Name of the configured destination chain, as NETWORK/CHAIN or CHAIN")
+	sendCmd.Flags().StringVar(&sendDestinationBlockchainID, "destination-blockchain-id", "",
+		"Blockchain ID of the destination chain")
+	sendCmd.Flags().StringVar(&sendDestinationAddress, "destination-address", "",
+		"Address of the contract on the destination chain that receives the message")
+	sendCmd.Flags().StringVar(&sendPayload, "payload", "", "Hex encoded message payload")
+	sendCmd.Flags().Uint64Var(&sendRequiredGasLimit, "required-gas-limit", defaultSendRequiredGasLimit,
+		"Gas limit required to execute the message on the destination chain")
+	sendCmd.Flags().StringVar(&sendFeeToken, "fee-token", "", "Address of the ERC20 relayer fee token")
+	sendCmd.Flags().StringVar(&sendFeeAmount, "fee-amount", "0", "Amount of the fee token paid to the relayer")
+	sendCmd.Flags().StringSliceVar(&sendAllowedRelayers, "allowed-relayer", nil,
+		"Address of a relayer allowed to deliver the message, may be repeated. Any relayer if not provided")
+	addSignerFlags(sendCmd, true)
+	sendCmd.Flags().Uint64Var(&sendGasLimit, "gas-limit", 0,
+		"Gas limit of the sendCrossChainMessage transaction, estimated if not provided")
+	cobra.CheckErr(sendCmd.MarkFlagRequired("destination-address"))
+	sendCmd.MarkFlagsOneRequired(destinationChainFlag, "destination-blockchain-id")
+	sendCmd.MarkFlagsMutuallyExclusive(destinationChainFlag, "destination-blockchain-id")
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"send"},
+			err:  fmt.Errorf("required flag(s)"),
+		},
+		{
+			name: "help",
+			args: []string{"send", "--help"},
+			err:  nil,
+			out:  "calling sendCrossChainMessage",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestBuildMessageInput(t *testing.T) {
+	blockchainID := ids.GenerateTestID()
+	cliConfig = &Config{
+		Networks: map[string]NetworkConfig{
+			"local": {
+				Chains: map[string]ChainConfig{
+					"subnet-b": {BlockchainID: blockchainID},
+					"subnet-c": {},
+				},
+			},
+		},
+	}
+	defer func() {
+		cliConfig = nil
+		sendDestinationChain = ""
+		sendDestinationBlockchainID = ""
+		sendDestinationAddress = ""
+		sendPayload = ""
+		sendFeeToken = ""
+		sendFeeAmount = "0"
+		sendAllowedRelayers = nil
+	}()
+
+	sendDestinationChain = "subnet-b"
+	sendDestinationAddress = "0x0000000000000000000000000000000000000002"
+	sendPayload = "0xcafe"
+	sendFeeToken = "0x0000000000000000000000000000000000000003"
+	sendFeeAmount = "10"
+	sendAllowedRelayers = []string{"0x0000000000000000000000000000000000000004"}
+	input, err := buildMessageInput()
+	require.NoError(t, err)
+	require.Equal(t, teleportermessenger.TeleporterMessageInput{
+		DestinationBlockchainID: blockchainID,
+		DestinationAddress:      common.HexToAddress("0x02"),
+		FeeInfo: teleportermessenger.TeleporterFeeInfo{
+			FeeTokenAddress: common.HexToAddress("0x03"),
+			Amount:          big.NewInt(10),
+		},
+		RequiredGasLimit:        new(big.Int).SetUint64(sendRequiredGasLimit),
+		AllowedRelayerAddresses: []common.Address{common.HexToAddress("0x04")},
+		Message:                 []byte{0xca, 0xfe},
+	}, input)
+
+	sendDestinationChain = "subnet-c"
+	_, err = buildMessageInput()
+	require.ErrorContains(t, err, "chain local/subnet-c has no blockchainID")
+
+	sendDestinationChain = ""
+	sendDestinationBlockchainID = blockchainID.String()
+	sendFeeToken = ""
+	_, err = buildMessageInput()
+	require.ErrorContains(t, err, "--fee-token is required")
+
+	sendFeeAmount = "0"
+	input, err = buildMessageInput()
+	require.NoError(t, err)
+	require.Equal(t, blockchainID, ids.ID(input.DestinationBlockchainID))
+	require.Equal(t, common.Address{}, input.FeeInfo.FeeTokenAddress)
+}
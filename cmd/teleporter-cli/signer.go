@@ -0,0 +1,254 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ava-labs/subnet-evm/accounts/keystore"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/rpc"
+	teleporterclient "github.com/ava-labs/teleporter/pkg/client"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+const (
+	keyFileFlag       = "key-file"
+	privateKeyEnvFlag = "private-key-env"
+	keystoreFlag      = "keystore"
+	passwordFileFlag  = "password-file"
+	signerURLFlag     = "signer-url"
+	signerAddressFlag = "signer-address"
+)
+
+var (
+	errNoSigner = errors.New("a signer is required, set one of --key-file, --private-key-env, --keystore or --signer-url")
+
+	signerOpts signerConfig
+)
+
+// signerConfig holds the signer flags of a command. At most one signer may be set.
+type signerConfig struct {
+	keyFile       string
+	privateKeyEnv string
+	keystore      string
+	passwordFile  string
+	signerURL     string
+	signerAddress string
+}
+
+// addSignerFlags adds the flags selecting the signer of a command's transactions. If required,
+// a signer must be set for the command to run.
+func addSignerFlags(cmd *cobra.Command, required bool) {
+	cmd.Flags().StringVar(&signerOpts.keyFile, keyFileFlag, "",
+		"File containing the hex encoded private key used to sign transactions")
+	cmd.Flags().StringVar(&signerOpts.privateKeyEnv, privateKeyEnvFlag, "",
+		"Name of the environment variable containing the hex encoded private key used to sign transactions")
+	cmd.Flags().StringVar(&signerOpts.keystore, keystoreFlag, "",
+		"Encrypted keystore file containing the key used to sign transactions")
+	cmd.Flags().StringVar(&signerOpts.passwordFile, passwordFileFlag, "",
+		"File containing the password of the keystore file")
+	cmd.Flags().StringVar(&signerOpts.signerURL, signerURLFlag, "",
+		"URL of an external signer supporting eth_signTransaction, such as Clef or Web3Signer")
+	cmd.Flags().StringVar(&signerOpts.signerAddress, signerAddressFlag, "",
+		"Address of the account used by the external signer")
+
+	signers := []string{keyFileFlag, privateKeyEnvFlag, keystoreFlag, signerURLFlag}
+	cmd.MarkFlagsMutuallyExclusive(signers...)
+	if required {
+		cmd.MarkFlagsOneRequired(signers...)
+	}
+	cmd.MarkFlagsRequiredTogether(keystoreFlag, passwordFileFlag)
+	cmd.MarkFlagsRequiredTogether(signerURLFlag, signerAddressFlag)
+}
+
+// isSet returns whether any signer was given
+func (c *signerConfig) isSet() bool {
+	return c.keyFile != "" || c.privateKeyEnv != "" || c.keystore != "" || c.signerURL != ""
+}
+
+// newSigner returns the signer selected by the signer flags. Private keys are never passed on the
+// command line, and are either read from a file or the environment, or held by an external signer.
+func newSigner(ctx context.Context) (teleporterclient.Signer, error) {
+	c := &signerOpts
+	switch {
+	case c.keyFile != "":
+		key, err := loadPrivateKey(c.keyFile)
+		if err != nil {
+			return nil, err
+		}
+		return teleporterclient.NewKeySigner(key), nil
+	case c.privateKeyEnv != "":
+		keyHex, ok := os.LookupEnv(c.privateKeyEnv)
+		if !ok {
+			return nil, fmt.Errorf("environment variable %s is not set", c.privateKeyEnv)
+		}
+		key, err := parsePrivateKey(keyHex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse private key in %s: %w", c.privateKeyEnv, err)
+		}
+		return teleporterclient.NewKeySigner(key), nil
+	case c.keystore != "":
+		key, err := loadKeystore(c.keystore, c.passwordFile)
+		if err != nil {
+			return nil, err
+		}
+		return teleporterclient.NewKeySigner(key), nil
+	case c.signerURL != "":
+		address, err := parseAddress(c.signerAddress)
+		if err != nil {
+			return nil, err
+		}
+		return newRemoteSigner(ctx, c.signerURL, address)
+	default:
+		return nil, errNoSigner
+	}
+}
+
+// loadPrivateKey reads a hex encoded private key from a file
+func loadPrivateKey(keyFile string) (*ecdsa.PrivateKey, error) {
+	b, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	key, err := parsePrivateKey(string(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key file: %w", err)
+	}
+	return key, nil
+}
+
+func parsePrivateKey(s string) (*ecdsa.PrivateKey, error) {
+	return crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(s), "0x"))
+}
+
+// loadKeystore decrypts a geth encrypted keystore file with the password in the password file
+func loadKeystore(keystoreFile string, passwordFile string) (*ecdsa.PrivateKey, error) {
+	keyJSON, err := os.ReadFile(keystoreFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore file: %w", err)
+	}
+	password, err := os.ReadFile(passwordFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read password file: %w", err)
+	}
+	key, err := keystore.DecryptKey(keyJSON, strings.TrimRight(string(password), "\r\n"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore file: %w", err)
+	}
+	return key.PrivateKey, nil
+}
+
+// remoteSigner signs transactions with the eth_signTransaction method of an external signer
+type remoteSigner struct {
+	client  *rpc.Client
+	address common.Address
+}
+
+// signTransactionArgs are the transaction arguments of eth_signTransaction
+type signTransactionArgs struct {
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas"`
+	Value                *hexutil.Big    `json:"value"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Data                 hexutil.Bytes   `json:"data"`
+	ChainID              *hexutil.Big    `json:"chainId"`
+}
+
+func newRemoteSigner(ctx context.Context, url string, address common.Address) (*remoteSigner, error) {
+	client, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to signer: %w", err)
+	}
+	return &remoteSigner{client: client, address: address}, nil
+}
+
+func (s *remoteSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *remoteSigner) SignTx(
+	ctx context.Context,
+	tx *types.Transaction,
+	chainID *big.Int,
+) (*types.Transaction, error) {
+	args := signTransactionArgs{
+		From:                 s.address,
+		To:                   tx.To(),
+		Gas:                  hexutil.Uint64(tx.Gas()),
+		MaxFeePerGas:         (*hexutil.Big)(tx.GasFeeCap()),
+		MaxPriorityFeePerGas: (*hexutil.Big)(tx.GasTipCap()),
+		Value:                (*hexutil.Big)(tx.Value()),
+		Nonce:                hexutil.Uint64(tx.Nonce()),
+		Data:                 tx.Data(),
+		ChainID:              (*hexutil.Big)(chainID),
+	}
+	var result json.RawMessage
+	if err := s.client.CallContext(ctx, &result, "eth_signTransaction", args); err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	raw, err := decodeSignTransactionResult(result)
+	if err != nil {
+		return nil, err
+	}
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("failed to decode signed transaction: %w", err)
+	}
+	if err := checkSignedTx(tx, signedTx, chainID, s.address); err != nil {
+		return nil, err
+	}
+	return signedTx, nil
+}
+
+// decodeSignTransactionResult returns the raw signed transaction from the result of eth_signTransaction.
+// Web3Signer returns the raw transaction, and Clef returns an object containing it.
+func decodeSignTransactionResult(result json.RawMessage) ([]byte, error) {
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(result, &raw); err == nil {
+		return raw, nil
+	}
+	var clefResult struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+	if err := json.Unmarshal(result, &clefResult); err != nil || len(clefResult.Raw) == 0 {
+		return nil, fmt.Errorf("unexpected eth_signTransaction result %s", string(result))
+	}
+	return clefResult.Raw, nil
+}
+
+// checkSignedTx checks that an external signer signed the requested transaction with the expected account
+func checkSignedTx(tx *types.Transaction, signedTx *types.Transaction, chainID *big.Int, from common.Address) error {
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signedTx)
+	if err != nil {
+		return fmt.Errorf("failed to recover signer of transaction: %w", err)
+	}
+	if sender != from {
+		return fmt.Errorf("transaction was signed by %s instead of %s", sender, from)
+	}
+	if signedTx.Nonce() != tx.Nonce() ||
+		(signedTx.To() == nil) != (tx.To() == nil) ||
+		(tx.To() != nil && *signedTx.To() != *tx.To()) ||
+		signedTx.Gas() != tx.Gas() ||
+		signedTx.Value().Cmp(tx.Value()) != 0 ||
+		signedTx.GasFeeCap().Cmp(tx.GasFeeCap()) != 0 ||
+		signedTx.GasTipCap().Cmp(tx.GasTipCap()) != 0 ||
+		string(signedTx.Data()) != string(tx.Data()) {
+		return errors.New("signed transaction does not match the requested transaction")
+	}
+	return nil
+}
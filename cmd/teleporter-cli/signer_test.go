@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/accounts/keystore"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// testSignerService implements eth_signTransaction in the style of Clef or Web3Signer
+type testSignerService struct {
+	key  *ecdsa.PrivateKey
+	clef bool
+	// If set, the transaction is signed with a different nonce than requested
+	tamper bool
+}
+
+func (s *testSignerService) SignTransaction(args signTransactionArgs) (interface{}, error) {
+	nonce := uint64(args.Nonce)
+	if s.tamper {
+		nonce++
+	}
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   args.ChainID.ToInt(),
+		Nonce:     nonce,
+		To:        args.To,
+		Gas:       uint64(args.Gas),
+		GasFeeCap: args.MaxFeePerGas.ToInt(),
+		GasTipCap: args.MaxPriorityFeePerGas.ToInt(),
+		Value:     args.Value.ToInt(),
+		Data:      args.Data,
+	})
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(args.ChainID.ToInt()), s.key)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if s.clef {
+		return map[string]interface{}{"raw": hexutil.Bytes(raw), "tx": signedTx}, nil
+	}
+	return hexutil.Bytes(raw), nil
+}
+
+func newTestSignerServer(t *testing.T, service *testSignerService) string {
+	server := rpc.NewServer(0)
+	require.NoError(t, server.RegisterName("eth", service))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+	return httpServer.URL
+}
+
+func newTestTx() *types.Transaction {
+	to := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(43112),
+		Nonce:     7,
+		To:        &to,
+		Gas:       21_000,
+		GasFeeCap: big.NewInt(50),
+		GasTipCap: big.NewInt(1),
+		Value:     big.NewInt(0),
+		Data:      []byte{0xca, 0xfe},
+	})
+}
+
+func TestNewSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	dir := t.TempDir()
+
+	keyFile := filepath.Join(dir, "key.txt")
+	require.NoError(t, os.WriteFile(keyFile, []byte(hexutil.Encode(crypto.FromECDSA(key))+"\n"), 0o600))
+
+	const envVar = "TELEPORTER_CLI_TEST_PRIVATE_KEY"
+	t.Setenv(envVar, hexutil.Encode(crypto.FromECDSA(key))[2:])
+
+	ks := keystore.NewKeyStore(filepath.Join(dir, "keystore"), keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.ImportECDSA(key, "password")
+	require.NoError(t, err)
+	passwordFile := filepath.Join(dir, "password.txt")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("password\n"), 0o600))
+	wrongPasswordFile := filepath.Join(dir, "wrong-password.txt")
+	require.NoError(t, os.WriteFile(wrongPasswordFile, []byte("wrong"), 0o600))
+
+	web3SignerURL := newTestSignerServer(t, &testSignerService{key: key})
+	clefURL := newTestSignerServer(t, &testSignerService{key: key, clef: true})
+	tamperingURL := newTestSignerServer(t, &testSignerService{key: key, tamper: true})
+
+	var tests = []struct {
+		name    string
+		config  signerConfig
+		err     string
+		signErr string
+	}{
+		{
+			name:   "key file",
+			config: signerConfig{keyFile: keyFile},
+		},
+		{
+			name:   "environment",
+			config: signerConfig{privateKeyEnv: envVar},
+		},
+		{
+			name:   "unset environment",
+			config: signerConfig{privateKeyEnv: "TELEPORTER_CLI_TEST_UNSET"},
+			err:    "environment variable TELEPORTER_CLI_TEST_UNSET is not set",
+		},
+		{
+			name:   "keystore",
+			config: signerConfig{keystore: account.URL.Path, passwordFile: passwordFile},
+		},
+		{
+			name:   "keystore wrong password",
+			config: signerConfig{keystore: account.URL.Path, passwordFile: wrongPasswordFile},
+			err:    "failed to decrypt keystore file",
+		},
+		{
+			name:   "web3signer",
+			config: signerConfig{signerURL: web3SignerURL, signerAddress: address.Hex()},
+		},
+		{
+			name:   "clef",
+			config: signerConfig{signerURL: clefURL, signerAddress: address.Hex()},
+		},
+		{
+			name:    "remote signer wrong account",
+			config:  signerConfig{signerURL: web3SignerURL, signerAddress: common.HexToAddress("0x02").Hex()},
+			signErr: "transaction was signed by",
+		},
+		{
+			name:    "remote signer modified transaction",
+			config:  signerConfig{signerURL: tamperingURL, signerAddress: address.Hex()},
+			signErr: "signed transaction does not match the requested transaction",
+		},
+		{
+			name: "none",
+			err:  errNoSigner.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signerOpts = tt.config
+			defer func() { signerOpts = signerConfig{} }()
+			ctx := context.Background()
+
+			signer, err := newSigner(ctx)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+
+			tx := newTestTx()
+			signedTx, err := signer.SignTx(ctx, tx, tx.ChainId())
+			if tt.signErr != "" {
+				require.ErrorContains(t, err, tt.signErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, address, signer.Address())
+			sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), signedTx)
+			require.NoError(t, err)
+			require.Equal(t, address, sender)
+			require.Equal(t, tx.Hash(), types.NewTx(&types.DynamicFeeTx{
+				ChainID:   signedTx.ChainId(),
+				Nonce:     signedTx.Nonce(),
+				To:        signedTx.To(),
+				Gas:       signedTx.Gas(),
+				GasFeeCap: signedTx.GasFeeCap(),
+				GasTipCap: signedTx.GasTipCap(),
+				Value:     signedTx.Value(),
+				Data:      signedTx.Data(),
+			}).Hash())
+		})
+	}
+}
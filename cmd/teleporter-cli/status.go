@@ -0,0 +1,145 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	teleporterclient "github.com/ava-labs/teleporter/pkg/client"
+	teleporterutils "github.com/ava-labs/teleporter/utils/teleporter-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statusNonce                   string
+	statusSourceBlockchainID      string
+	statusDestinationBlockchainID string
+)
+
+// messageStatusOutput is the on-chain state of a Teleporter message on its source and destination chains
+type messageStatusOutput struct {
+	MessageID            ids.ID                                `json:"messageID"`
+	Status               string                                `json:"status"`
+	Received             bool                                  `json:"received"`
+	RelayerRewardAddress common.Address                        `json:"relayerRewardAddress"`
+	FeeInfo              teleportermessenger.TeleporterFeeInfo `json:"feeInfo"`
+	MessageHash          common.Hash                           `json:"messageHash"`
+	FailedMessageHash    common.Hash                           `json:"failedMessageHash"`
+}
+
+var statusCmd = &cobra.Command{
+	Use: "status --source-rpc SOURCE_RPC_URL --dest-rpc DEST_RPC_URL " +
+		"--teleporter-address CONTRACT_ADDRESS [MESSAGE_ID]",
+	Short: "Queries the delivery state of a Teleporter message",
+	Long: `Given a Teleporter message ID, or the destination blockchain ID and nonce of the
+message, this command queries the Teleporter contract on the destination chain for
+whether the message was received and by which relayer, and the Teleporter contract
+on the source chain for the message's fee and hash. The result is summarized as one
+of pending, delivered, delivered-but-execution-failed, or receipt-returned.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		var (
+			messageID ids.ID
+			err       error
+		)
+		if len(args) == 1 {
+			messageID, err = parseID(args[0])
+		} else {
+			messageID, err = calculateStatusMessageID(ctx)
+		}
+		cobra.CheckErr(err)
+
+		out, err := queryMessageStatus(ctx, messageID)
+		cobra.CheckErr(err)
+		cobra.CheckErr(printOutput(cmd, out))
+		cmd.Println("Status command ran successfully")
+	},
+}
+
+// calculateStatusMessageID derives the message ID from the source and destination blockchain IDs and
+// the message nonce. The source blockchain ID is queried from the source chain if it is not provided.
+func calculateStatusMessageID(ctx context.Context) (ids.ID, error) {
+	if statusNonce == "" || statusDestinationBlockchainID == "" {
+		return ids.ID{}, errors.New("either a message ID or --destination-blockchain-id and --nonce must be provided")
+	}
+	nonce, err := parseBigInt(statusNonce)
+	if err != nil {
+		return ids.ID{}, err
+	}
+	destinationBlockchainID, err := parseID(statusDestinationBlockchainID)
+	if err != nil {
+		return ids.ID{}, err
+	}
+
+	var sourceBlockchainID ids.ID
+	if statusSourceBlockchainID != "" {
+		sourceBlockchainID, err = parseID(statusSourceBlockchainID)
+		if err != nil {
+			return ids.ID{}, err
+		}
+	} else {
+		sourceMessenger, err := teleportermessenger.NewTeleporterMessenger(teleporterAddress, sourceClient)
+		if err != nil {
+			return ids.ID{}, err
+		}
+		sourceBlockchainID, err = sourceMessenger.BlockchainID(&bind.CallOpts{Context: ctx})
+		if err != nil {
+			return ids.ID{}, err
+		}
+	}
+	return teleporterutils.CalculateMessageID(teleporterAddress, sourceBlockchainID, destinationBlockchainID, nonce)
+}
+
+func queryMessageStatus(ctx context.Context, messageID ids.ID) (*messageStatusOutput, error) {
+	source, err := newTeleporterClient(ctx, sourceClient, nil)
+	if err != nil {
+		return nil, err
+	}
+	dest, err := newTeleporterClient(ctx, destClient, nil)
+	if err != nil {
+		return nil, err
+	}
+	sourceStatus, err := source.Status(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+	destStatus, err := dest.Status(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+	return newMessageStatusOutput(sourceStatus, destStatus), nil
+}
+
+// newMessageStatusOutput combines the state of a message on its source and destination chains
+func newMessageStatusOutput(
+	source *teleporterclient.MessageStatus,
+	destination *teleporterclient.MessageStatus,
+) *messageStatusOutput {
+	return &messageStatusOutput{
+		MessageID:            source.MessageID,
+		Status:               teleporterclient.Delivery(source, destination).String(),
+		Received:             destination.Received,
+		RelayerRewardAddress: destination.RelayerRewardAddress,
+		FeeInfo:              source.FeeInfo,
+		MessageHash:          source.MessageHash,
+		FailedMessageHash:    destination.FailedMessageHash,
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+	addSourceDestinationFlags(statusCmd)
+	statusCmd.Flags().StringVar(&statusNonce, "nonce", "",
+		"Nonce of the message, used to calculate the message ID if it is not provided")
+	statusCmd.Flags().StringVar(&statusSourceBlockchainID, "source-blockchain-id", "",
+		"Blockchain ID of the source chain, queried from the source chain if not provided")
+	statusCmd.Flags().StringVar(&statusDestinationBlockchainID, "destination-blockchain-id", "",
+		"Blockchain ID of the destination chain, used to calculate the message ID if it is not provided")
+}
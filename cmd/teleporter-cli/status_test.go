@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	teleporterclient "github.com/ava-labs/teleporter/pkg/client"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"status"},
+			err:  fmt.Errorf("required flag(s) \"dest-rpc\", \"source-rpc\", \"teleporter-address\" not set"),
+		},
+		{
+			name: "help",
+			args: []string{"status", "--help"},
+			err:  nil,
+			out:  "Given a Teleporter message ID, or the destination blockchain ID and nonce",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestNewMessageStatusOutput(t *testing.T) {
+	hash := common.HexToHash("0x01")
+	relayer := common.HexToAddress("0x02")
+	var tests = []struct {
+		name        string
+		source      teleporterclient.MessageStatus
+		destination teleporterclient.MessageStatus
+		expected    string
+	}{
+		{
+			name:     "unknown",
+			expected: "unknown",
+		},
+		{
+			name:     "pending",
+			source:   teleporterclient.MessageStatus{MessageHash: hash},
+			expected: "pending",
+		},
+		{
+			name:        "delivered",
+			source:      teleporterclient.MessageStatus{MessageHash: hash},
+			destination: teleporterclient.MessageStatus{Received: true, RelayerRewardAddress: relayer},
+			expected:    "delivered",
+		},
+		{
+			name:   "execution failed",
+			source: teleporterclient.MessageStatus{MessageHash: hash},
+			destination: teleporterclient.MessageStatus{
+				Received:             true,
+				RelayerRewardAddress: relayer,
+				FailedMessageHash:    hash,
+			},
+			expected: "delivered-but-execution-failed",
+		},
+		{
+			name:        "receipt returned",
+			destination: teleporterclient.MessageStatus{Received: true, RelayerRewardAddress: relayer},
+			expected:    "receipt-returned",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := newMessageStatusOutput(&tt.source, &tt.destination)
+			require.Equal(t, tt.expected, out.Status)
+			require.Equal(t, tt.destination.Received, out.Received)
+			require.Equal(t, tt.destination.RelayerRewardAddress, out.RelayerRewardAddress)
+			require.Equal(t, tt.destination.FailedMessageHash, out.FailedMessageHash)
+			require.Equal(t, tt.source.MessageHash, out.MessageHash)
+		})
+	}
+}
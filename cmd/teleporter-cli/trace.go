@@ -0,0 +1,233 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var traceFromBlock uint64
+
+// traceHop is a single step in the life of a Teleporter message.
+type traceHop struct {
+	Chain       string
+	Event       string
+	BlockNumber uint64
+	Timestamp   uint64
+	TxHash      common.Hash
+	Details     interface{}
+}
+
+var traceCmd = &cobra.Command{
+	Use: "trace --source-rpc SOURCE_RPC_URL --dest-rpc DEST_RPC_URL " +
+		"--teleporter-address CONTRACT_ADDRESS TRANSACTION_HASH",
+	Short: "Follows a Teleporter message from its source transaction to delivery and receipt",
+	Long: `Given the hash of a transaction on the source chain that sent a Teleporter message,
+this command extracts the SendCrossChainMessage event and message ID, searches the
+destination chain from --from-block for the corresponding ReceiveCrossChainMessage,
+MessageExecuted and MessageExecutionFailed events, and finally searches the source
+chain from the sending block for the ReceiptReceived event. Each hop found is
+printed as a timeline.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		hops, err := traceMessage(context.Background(), common.HexToHash(args[0]))
+		cobra.CheckErr(err)
+		cobra.CheckErr(printOutput(cmd, hops))
+		cmd.Println("Trace command ran successfully")
+	},
+}
+
+func traceMessage(ctx context.Context, txHash common.Hash) ([]traceHop, error) {
+	sourceMessenger, err := teleportermessenger.NewTeleporterMessenger(teleporterAddress, sourceClient)
+	if err != nil {
+		return nil, err
+	}
+	destMessenger, err := teleportermessenger.NewTeleporterMessenger(teleporterAddress, destClient)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceReceipt, err := sourceClient.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var sendEvent *teleportermessenger.TeleporterMessengerSendCrossChainMessage
+	for _, log := range sourceReceipt.Logs {
+		if log.Address != teleporterAddress {
+			continue
+		}
+		event, err := sourceMessenger.ParseSendCrossChainMessage(*log)
+		if err == nil {
+			sendEvent = event
+			break
+		}
+	}
+	if sendEvent == nil {
+		return nil, fmt.Errorf("no SendCrossChainMessage event found in transaction %s", txHash.Hex())
+	}
+	messageID := ids.ID(sendEvent.MessageID)
+	logger.Debug("Found SendCrossChainMessage event", zap.Stringer("messageID", messageID))
+
+	sourceBlockchainID, err := sourceMessenger.BlockchainID(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, err
+	}
+
+	hop, err := newTraceHop(ctx, sourceClient, sourceChainLabel, sendEvent.Raw, sendEvent)
+	if err != nil {
+		return nil, err
+	}
+	hops := []traceHop{hop}
+
+	delivered, err := destMessenger.MessageReceived(&bind.CallOpts{Context: ctx}, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if !delivered {
+		logger.Info("Message has not been delivered to the destination chain",
+			zap.Stringer("messageID", messageID))
+		return hops, nil
+	}
+
+	// The delivery block on the destination chain is unrelated to the source block height,
+	// so search the destination chain from --from-block.
+	destHeight, err := latestBlockFrom(ctx, destClient, traceFromBlock)
+	if err != nil {
+		return nil, err
+	}
+	deliveryEvents, err := findMessageDelivery(ctx, destClient, messageID, sourceBlockchainID, traceFromBlock, destHeight)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range deliveryEvents {
+		hop, err = newTraceHop(ctx, destClient, destinationChainLabel, event.Log(), eventDetails(event))
+		if err != nil {
+			return nil, err
+		}
+		hops = append(hops, hop)
+	}
+
+	sourceHeight, err := sourceClient.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	receiptEvents, err := filterTeleporterEvents(ctx, sourceClient, [][]common.Hash{
+		{teleporterABI.Events["ReceiptReceived"].ID},
+		{common.Hash(messageID)},
+		{common.Hash(sendEvent.DestinationBlockchainID)},
+	}, sendEvent.Raw.BlockNumber, sourceHeight)
+	if err != nil {
+		return nil, err
+	}
+	if len(receiptEvents) > 0 {
+		hop, err = newTraceHop(ctx, sourceClient, sourceChainLabel, receiptEvents[0].Log(), eventDetails(receiptEvents[0]))
+		if err != nil {
+			return nil, err
+		}
+		hops = append(hops, hop)
+	} else {
+		logger.Info("Receipt for the message has not yet been returned to the source chain",
+			zap.Stringer("messageID", messageID))
+	}
+
+	return hops, nil
+}
+
+// findMessageDelivery returns the ReceiveCrossChainMessage event of the message between fromBlock and
+// toBlock inclusive, followed by its MessageExecuted and MessageExecutionFailed events in block order.
+// Execution either happens in the delivery transaction, or in a later retry.
+func findMessageDelivery(
+	ctx context.Context,
+	filterer logFilterer,
+	messageID ids.ID,
+	sourceBlockchainID ids.ID,
+	fromBlock uint64,
+	toBlock uint64,
+) ([]teleportermessenger.TeleporterEvent, error) {
+	receiveEvents, err := filterTeleporterEvents(ctx, filterer, [][]common.Hash{
+		{teleporterABI.Events["ReceiveCrossChainMessage"].ID},
+		{common.Hash(messageID)},
+		{common.Hash(sourceBlockchainID)},
+	}, fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	if len(receiveEvents) == 0 {
+		return nil, errors.New("message was delivered but no ReceiveCrossChainMessage log was found, " +
+			"try decreasing --from-block")
+	}
+	receiveEvent := receiveEvents[0]
+
+	executionEvents, err := filterTeleporterEvents(ctx, filterer, [][]common.Hash{
+		{teleporterABI.Events["MessageExecuted"].ID, teleporterABI.Events["MessageExecutionFailed"].ID},
+		{common.Hash(messageID)},
+		{common.Hash(sourceBlockchainID)},
+	}, receiveEvent.Log().BlockNumber, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	return append([]teleportermessenger.TeleporterEvent{receiveEvent}, executionEvents...), nil
+}
+
+// eventDetails returns the generated event struct of a parsed Teleporter event, to print its fields
+// without the wrapper
+func eventDetails(event teleportermessenger.TeleporterEvent) interface{} {
+	switch e := event.(type) {
+	case *teleportermessenger.ReceiveCrossChainMessageEvent:
+		return &e.TeleporterMessengerReceiveCrossChainMessage
+	case *teleportermessenger.MessageExecutedEvent:
+		return &e.TeleporterMessengerMessageExecuted
+	case *teleportermessenger.MessageExecutionFailedEvent:
+		return &e.TeleporterMessengerMessageExecutionFailed
+	case *teleportermessenger.ReceiptReceivedEvent:
+		return &e.TeleporterMessengerReceiptReceived
+	default:
+		return event
+	}
+}
+
+func newTraceHop(
+	ctx context.Context,
+	client ethclient.Client,
+	chain string,
+	log types.Log,
+	event interface{},
+) (traceHop, error) {
+	teleporterEvent, err := teleporterABI.EventByID(log.Topics[0])
+	if err != nil {
+		return traceHop{}, err
+	}
+	header, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(log.BlockNumber))
+	if err != nil {
+		return traceHop{}, err
+	}
+	return traceHop{
+		Chain:       chain,
+		Event:       teleporterEvent.Name,
+		BlockNumber: log.BlockNumber,
+		Timestamp:   header.Time,
+		TxHash:      log.TxHash,
+		Details:     event,
+	}, nil
+}
+
+func init() {
+	rootCmd.AddCommand(traceCmd)
+	addSourceDestinationFlags(traceCmd)
+	traceCmd.Flags().Uint64Var(&traceFromBlock, "from-block", 0,
+		"First block on the destination chain to search for the message delivery")
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/subnet-evm/core/types"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"trace"},
+			err:  fmt.Errorf("accepts 1 arg(s), received 0"),
+		},
+		{
+			name: "help",
+			args: []string{"trace", "--help"},
+			err:  nil,
+			out:  "Given the hash of a transaction on the source chain that sent a Teleporter message",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestFindMessageDelivery(t *testing.T) {
+	logger = logging.NoLog{}
+	abi, err := teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	require.NoError(t, err)
+	teleporterABI = abi
+
+	messageID := ids.GenerateTestID()
+	sourceBlockchainID := ids.GenerateTestID()
+	message := teleportermessenger.TeleporterMessage{
+		MessageNonce:            big.NewInt(1),
+		RequiredGasLimit:        big.NewInt(1),
+		AllowedRelayerAddresses: []common.Address{},
+		Receipts:                []teleportermessenger.TeleporterMessageReceipt{},
+		Message:                 []byte{},
+	}
+	event := func(block uint64, name string, args ...interface{}) types.Log {
+		topics, data, err := teleporterABI.PackEvent(name, args...)
+		require.NoError(t, err)
+		return types.Log{Topics: topics, Data: data, BlockNumber: block}
+	}
+	deliverer := common.HexToAddress("0x01")
+	// The message was delivered many pages before the latest block, and its failed execution was retried later
+	filterer := &blockLogFilterer{logs: []types.Log{
+		event(10, "ReceiveCrossChainMessage", messageID, sourceBlockchainID, deliverer, deliverer, message),
+		event(10, "MessageExecutionFailed", messageID, sourceBlockchainID, message),
+		event(2*defaultHistoryPageSize, "MessageExecuted", ids.GenerateTestID(), sourceBlockchainID),
+		event(3*defaultHistoryPageSize, "MessageExecuted", messageID, sourceBlockchainID),
+	}}
+
+	events, err := findMessageDelivery(context.Background(), filterer, messageID, sourceBlockchainID, 0,
+		5*defaultHistoryPageSize)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	require.Equal(t, teleportermessenger.ReceiveCrossChainMessage, events[0].Event())
+	require.Equal(t, teleportermessenger.MessageExecutionFailed, events[1].Event())
+	require.Equal(t, teleportermessenger.MessageExecuted, events[2].Event())
+	require.Equal(t, uint64(3*defaultHistoryPageSize), events[2].Log().BlockNumber)
+	for _, event := range events {
+		require.Equal(t, messageID, event.GetMessageID())
+	}
+
+	_, err = findMessageDelivery(context.Background(), filterer, messageID, sourceBlockchainID, 11,
+		5*defaultHistoryPageSize)
+	require.ErrorContains(t, err, "try decreasing --from-block")
+}
@@ -6,7 +6,9 @@ package main
 import (
 	"context"
 
+	"github.com/ava-labs/avalanchego/ids"
 	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
 	"github.com/ava-labs/subnet-evm/ethclient"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
 	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
@@ -19,11 +21,27 @@ const (
 	warpPrecompileAddress = "0x0200000000000000000000000000000000000005"
 )
 
-var (
-	rpcEndpoint       string
-	teleporterAddress common.Address
-	client            ethclient.Client
-)
+// transactionOutput holds the Teleporter events and Warp messages found in a transaction
+type transactionOutput struct {
+	TxHash           common.Hash         `json:"txHash"`
+	TeleporterEvents []eventOutput       `json:"teleporterEvents"`
+	WarpMessages     []warpMessageOutput `json:"warpMessages"`
+	Deliveries       []deliveryOutput    `json:"deliveries,omitempty"`
+}
+
+// deliveryOutput is whether a message sent in the transaction was received on its destination chain,
+// which is only checked for destination chains with an RPC endpoint in the configuration file
+type deliveryOutput struct {
+	MessageID               ids.ID `json:"messageID"`
+	DestinationBlockchainID ids.ID `json:"destinationBlockchainID"`
+	Received                bool   `json:"received"`
+}
+
+// warpMessageOutput is a Teleporter message sent through the Warp precompile
+type warpMessageOutput struct {
+	WarpMessageID ids.ID                                 `json:"warpMessageID"`
+	Message       *teleportermessenger.TeleporterMessage `json:"message"`
+}
 
 var transactionCmd = &cobra.Command{
 	Use:   "transaction --rpc RPC_URL --teleporter-address CONTRACT_ADDRESS TRANSACTION_HASH",
@@ -37,63 +55,102 @@ the command parses to log event fields to a more human readable format.`,
 			common.HexToHash(args[0]))
 		cobra.CheckErr(err)
 
+		out := transactionOutput{
+			TxHash:           receipt.TxHash,
+			TeleporterEvents: []eventOutput{},
+			WarpMessages:     []warpMessageOutput{},
+		}
 		for _, log := range receipt.Logs {
 			if log.Address == teleporterAddress {
-				logger.Info("Processing Teleporter log", zap.Any("log", log))
+				logger.Debug("Processing Teleporter log", zap.Any("log", log))
 
 				event, err := teleporterABI.EventByID(log.Topics[0])
 				cobra.CheckErr(err)
 
-				out, err := teleportermessenger.FilterTeleporterEvents(log.Topics, log.Data, event.Name)
+				parsed, err := teleportermessenger.FilterTeleporterEvents(log.Topics, log.Data, event.Name)
 				cobra.CheckErr(err)
-				logger.Info("Parsed Teleporter event", zap.String("name", event.Name), zap.Any("event", out))
+				out.TeleporterEvents = append(out.TeleporterEvents, eventOutput{Name: event.Name, Event: parsed})
+
+				if sendEvent, ok := parsed.(*teleportermessenger.TeleporterMessengerSendCrossChainMessage); ok {
+					if delivery := checkDelivery(context.Background(), sendEvent); delivery != nil {
+						out.Deliveries = append(out.Deliveries, *delivery)
+					}
+				}
 			}
 
 			if log.Address == common.HexToAddress(warpPrecompileAddress) {
 				logger.Debug("Processing Warp log", zap.Any("log", log))
 
-				unsignedMsg, err := warp.UnpackSendWarpEventDataToMessage(log.Data)
-				cobra.CheckErr(err)
-
-				warpPayload, err := warpPayload.ParseAddressedCall(unsignedMsg.Payload)
+				warpMessage, err := decodeWarpLog(log.Data)
 				cobra.CheckErr(err)
-
-				teleporterMessage, err := teleportermessenger.UnpackTeleporterMessage(warpPayload.Payload)
-				cobra.CheckErr(err)
-				logger.Info("Parsed Teleporter message",
-					zap.String("warpMessageID", unsignedMsg.ID().Hex()),
-					zap.String("teleporterMessageNonce", teleporterMessage.MessageNonce.String()),
-					zap.Any("message", teleporterMessage))
+				out.WarpMessages = append(out.WarpMessages, *warpMessage)
 			}
 		}
+		cobra.CheckErr(printOutput(cmd, out))
 		cmd.Println("Transaction command ran successfully")
 	},
 }
 
-func init() {
-	rootCmd.AddCommand(transactionCmd)
-	transactionCmd.PersistentFlags().StringVar(&rpcEndpoint, "rpc", "", "RPC endpoint to connect to the node")
-	address := transactionCmd.PersistentFlags().StringP("teleporter-address", "t", "", "Teleporter contract address")
-	err := transactionCmd.MarkPersistentFlagRequired("rpc")
-	cobra.CheckErr(err)
-	err = transactionCmd.MarkPersistentFlagRequired("teleporter-address")
-	cobra.CheckErr(err)
-	transactionCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
-		return transactionPreRunE(cmd, args, address)
+// checkDelivery queries the destination chain of a sent message for whether the message was received,
+// if the destination chain is configured. Returns nil if the destination chain can not be queried.
+func checkDelivery(
+	ctx context.Context,
+	event *teleportermessenger.TeleporterMessengerSendCrossChainMessage,
+) *deliveryOutput {
+	chain, ok := resolver.Resolve(event.DestinationBlockchainID)
+	if !ok || chain.RPCURL == "" {
+		return nil
+	}
+	destinationAddress := chain.TeleporterAddress
+	if destinationAddress == (common.Address{}) {
+		destinationAddress = teleporterAddress
+	}
+	received, err := func() (bool, error) {
+		c, err := ethclient.DialContext(ctx, chain.RPCURL)
+		if err != nil {
+			return false, err
+		}
+		defer c.Close()
+		messenger, err := teleportermessenger.NewTeleporterMessenger(destinationAddress, c)
+		if err != nil {
+			return false, err
+		}
+		return messenger.MessageReceived(&bind.CallOpts{Context: ctx}, event.MessageID)
+	}()
+	if err != nil {
+		logger.Warn("Failed to check message delivery on destination chain",
+			zap.String("destinationChain", chain.String()),
+			zap.Error(err))
+		return nil
+	}
+	return &deliveryOutput{
+		MessageID:               event.MessageID,
+		DestinationBlockchainID: event.DestinationBlockchainID,
+		Received:                received,
 	}
 }
 
-func transactionPreRunE(cmd *cobra.Command, args []string, address *string) error {
-	// Run the persistent pre-run function of the root command if it exists.
-	if err := callPersistentPreRunE(cmd, args); err != nil {
-		return err
+// decodeWarpLog decodes the data of a Warp precompile SendWarpMessage log into the Teleporter message it carries
+func decodeWarpLog(data []byte) (*warpMessageOutput, error) {
+	unsignedMsg, err := warp.UnpackSendWarpEventDataToMessage(data)
+	if err != nil {
+		return nil, err
+	}
+	warpPayload, err := warpPayload.ParseAddressedCall(unsignedMsg.Payload)
+	if err != nil {
+		return nil, err
 	}
-	teleporterAddress = common.HexToAddress(*address)
-	c, err := ethclient.Dial(rpcEndpoint)
+	teleporterMessage, err := teleportermessenger.UnpackTeleporterMessage(warpPayload.Payload)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return &warpMessageOutput{
+		WarpMessageID: unsignedMsg.ID(),
+		Message:       teleporterMessage,
+	}, nil
+}
 
-	client = c
-	return err
+func init() {
+	rootCmd.AddCommand(transactionCmd)
+	addRPCFlags(transactionCmd)
 }
@@ -0,0 +1,101 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	teleporterclient "github.com/ava-labs/teleporter/pkg/client"
+	transactionutils "github.com/ava-labs/teleporter/utils/transaction-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// callContract simulates a call to the given contract from the given address against the latest state
+func callContract(
+	ctx context.Context,
+	client ethclient.Client,
+	from common.Address,
+	to common.Address,
+	data []byte,
+) ([]byte, error) {
+	return client.CallContract(ctx, interfaces.CallMsg{
+		From: from,
+		To:   &to,
+		Data: data,
+	}, nil)
+}
+
+// sendTransaction signs and sends a transaction calling the given contract, and waits for it to be
+// accepted. If gasLimit is zero, the gas limit is estimated. Returns an error if the transaction was reverted.
+func sendTransaction(
+	ctx context.Context,
+	client ethclient.Client,
+	signer teleporterclient.Signer,
+	to common.Address,
+	data []byte,
+	value *big.Int,
+	gasLimit uint64,
+) (*types.Receipt, error) {
+	return signAndSendTransaction(ctx, client, signer, &to, data, value, gasLimit)
+}
+
+// deployContract signs and sends a transaction creating a contract with the given creation bytecode,
+// and waits for it to be accepted. If gasLimit is zero, the gas limit is estimated.
+func deployContract(
+	ctx context.Context,
+	client ethclient.Client,
+	signer teleporterclient.Signer,
+	bytecode []byte,
+	gasLimit uint64,
+) (*types.Receipt, error) {
+	return signAndSendTransaction(ctx, client, signer, nil, bytecode, nil, gasLimit)
+}
+
+func signAndSendTransaction(
+	ctx context.Context,
+	client ethclient.Client,
+	signer teleporterclient.Signer,
+	to *common.Address,
+	data []byte,
+	value *big.Int,
+	gasLimit uint64,
+) (*types.Receipt, error) {
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := transactionutils.NewTransaction(ctx, client, chainID, signer.Address(), to, gasLimit, value, data)
+	if err != nil {
+		return nil, err
+	}
+	signedTx, err := signer.SignTx(ctx, tx, chainID)
+	if err != nil {
+		return nil, err
+	}
+	return sendSignedTransaction(ctx, client, signedTx, signer.Address())
+}
+
+// sendSignedTransaction sends a signed transaction and waits for it to be accepted. Returns an error
+// if the transaction was reverted.
+func sendSignedTransaction(
+	ctx context.Context,
+	client ethclient.Client,
+	signedTx *types.Transaction,
+	from common.Address,
+) (*types.Receipt, error) {
+	to := "contract creation"
+	if signedTx.To() != nil {
+		to = signedTx.To().Hex()
+	}
+	logger.Info("Sending transaction, waiting for acceptance",
+		zap.String("txHash", signedTx.Hash().Hex()),
+		zap.String("from", from.Hex()),
+		zap.String("to", to))
+	return transactionutils.SendTransactionAndWaitForSuccess(ctx, logger, client, signedTx)
+}
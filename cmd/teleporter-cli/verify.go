@@ -0,0 +1,193 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/subnet-evm/ethclient"
+	deploymentUtils "github.com/ava-labs/teleporter/utils/deployment-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+const (
+	messengerContract = "messenger"
+	registryContract  = "registry"
+
+	verifyErrorStatus = "error"
+)
+
+var (
+	verifyArtifact string
+	verifyContract string
+	verifyAddress  string
+	verifyRPCs     []string
+	verifyChains   []string
+)
+
+// verifyResult is the result of verifying the deployed bytecode on a single chain
+type verifyResult struct {
+	Chain   string         `json:"chain"`
+	Address common.Address `json:"address"`
+	Status  string         `json:"status"`
+	Error   string         `json:"error,omitempty"`
+}
+
+// verifyOutput is the per-chain report of the verify command
+type verifyOutput struct {
+	Artifact string         `json:"artifact"`
+	Results  []verifyResult `json:"results"`
+}
+
+// verifyTarget is a chain to verify the deployed bytecode on
+type verifyTarget struct {
+	name    string
+	rpcURL  string
+	address common.Address
+}
+
+var verifyCmd = &cobra.Command{
+	Use: "verify --artifact ARTIFACT_FILE [--contract messenger|registry] [--address CONTRACT_ADDRESS] " +
+		"(--rpc RPC_URL | --chains CHAIN)...",
+	Short: "Verifies the bytecode of deployed Teleporter contracts against a build artifact",
+	Long: `Fetches the code deployed at the TeleporterMessenger or TeleporterRegistry address on
+each of the given chains with eth_getCode, and compares it against the runtime bytecode
+in a forge build artifact, ignoring the metadata hash appended by the compiler and the
+values of immutable variables. Chains are given by RPC endpoint with --rpc, or by name
+from the configuration file with --chains, and default to the chain selected with
+--chain. The contract address is given with --address, or read from the configured
+teleporterAddress or registryAddress of each chain according to --contract. A report
+of match, mismatch, or missing is printed for each chain, and the command fails if the
+bytecode does not match on every chain.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		out, err := verifyDeployments(context.Background())
+		cobra.CheckErr(err)
+		cobra.CheckErr(printOutput(cmd, out))
+
+		var failed []string
+		for _, result := range out.Results {
+			if result.Status != deploymentUtils.BytecodeMatch.String() {
+				failed = append(failed, result.Chain)
+			}
+		}
+		if len(failed) > 0 {
+			cobra.CheckErr(fmt.Errorf("bytecode does not match on %s", strings.Join(failed, ", ")))
+		}
+		cmd.Println("Verify command ran successfully")
+	},
+}
+
+func verifyDeployments(ctx context.Context) (*verifyOutput, error) {
+	if verifyContract != messengerContract && verifyContract != registryContract {
+		return nil, fmt.Errorf("unknown contract %s, must be %s or %s", verifyContract, messengerContract, registryContract)
+	}
+	expected, err := deploymentUtils.ExtractDeployedByteCode(verifyArtifact)
+	if err != nil {
+		return nil, err
+	}
+	targets, err := verifyTargets()
+	if err != nil {
+		return nil, err
+	}
+
+	out := &verifyOutput{
+		Artifact: verifyArtifact,
+		Results:  make([]verifyResult, 0, len(targets)),
+	}
+	for _, target := range targets {
+		result := verifyResult{Chain: target.name, Address: target.address}
+		status, err := verifyTargetBytecode(ctx, target, expected)
+		if err != nil {
+			result.Status = verifyErrorStatus
+			result.Error = err.Error()
+		} else {
+			result.Status = status.String()
+		}
+		out.Results = append(out.Results, result)
+	}
+	return out, nil
+}
+
+func verifyTargetBytecode(
+	ctx context.Context,
+	target verifyTarget,
+	expected *deploymentUtils.DeployedByteCode,
+) (deploymentUtils.BytecodeStatus, error) {
+	if target.address == (common.Address{}) {
+		return deploymentUtils.BytecodeMissing, fmt.Errorf("no %s address configured, use --address", verifyContract)
+	}
+	c, err := ethclient.DialContext(ctx, target.rpcURL)
+	if err != nil {
+		return deploymentUtils.BytecodeMissing, err
+	}
+	defer c.Close()
+	return deploymentUtils.VerifyDeployedByteCode(ctx, c, target.address, expected)
+}
+
+// verifyTargets returns the chains given by --rpc and --chains, or the chain selected with --chain
+func verifyTargets() ([]verifyTarget, error) {
+	var address common.Address
+	if verifyAddress != "" {
+		var err error
+		if address, err = parseAddress(verifyAddress); err != nil {
+			return nil, err
+		}
+	}
+
+	var targets []verifyTarget
+	for _, rpcURL := range verifyRPCs {
+		if address == (common.Address{}) {
+			return nil, errors.New("--address is required with --rpc")
+		}
+		targets = append(targets, verifyTarget{name: rpcURL, rpcURL: rpcURL, address: address})
+	}
+	var chains []*Chain
+	for _, name := range verifyChains {
+		chain, err := cliConfig.Chain(name)
+		if err != nil {
+			return nil, err
+		}
+		chains = append(chains, chain)
+	}
+	if len(targets) == 0 && len(chains) == 0 && selectedChain != nil {
+		chains = append(chains, selectedChain)
+	}
+	for _, chain := range chains {
+		if chain.RPCURL == "" {
+			return nil, fmt.Errorf("chain %s has no rpcURL in the config file", chain)
+		}
+		target := verifyTarget{name: chain.String(), rpcURL: chain.RPCURL, address: address}
+		if address == (common.Address{}) {
+			target.address = chain.TeleporterAddress
+			if verifyContract == registryContract {
+				target.address = chain.RegistryAddress
+			}
+		}
+		targets = append(targets, target)
+	}
+	if len(targets) == 0 {
+		return nil, errors.New("no chains to verify, use --rpc, --chains, or --chain")
+	}
+	return targets, nil
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+	verifyCmd.Flags().StringVar(&verifyArtifact, "artifact", "",
+		"Forge build artifact containing the deployedBytecode of the contract")
+	verifyCmd.Flags().StringVar(&verifyContract, "contract", messengerContract,
+		"Contract whose configured address is verified i.e. messenger, registry")
+	verifyCmd.Flags().StringVar(&verifyAddress, "address", "",
+		"Address of the contract on every chain, read from the configuration file if not provided")
+	verifyCmd.Flags().StringSliceVar(&verifyRPCs, rpcFlag, nil,
+		"RPC endpoint of a chain to verify, may be repeated")
+	verifyCmd.Flags().StringSliceVar(&verifyChains, "chains", nil,
+		"Name of a configured chain to verify, as NETWORK/CHAIN or CHAIN, may be repeated")
+	cobra.CheckErr(verifyCmd.MarkFlagRequired("artifact"))
+}
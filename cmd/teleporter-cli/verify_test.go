@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"verify"},
+			err:  fmt.Errorf("required flag(s) \"artifact\" not set"),
+		},
+		{
+			name: "help",
+			args: []string{"verify", "--help"},
+			err:  nil,
+			out:  "ignoring the metadata hash appended by the compiler",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestVerifyTargets(t *testing.T) {
+	teleporter := common.HexToAddress("0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf")
+	registry := common.HexToAddress("0xF86Cb19Ad8405AEFa7d09C778215D2Cb6eBfB228")
+	cliConfig = &Config{
+		Networks: map[string]NetworkConfig{
+			"fuji": {
+				Chains: map[string]ChainConfig{
+					"c-chain": {
+						RPCURL:            "https://api.avax-test.network/ext/bc/C/rpc",
+						TeleporterAddress: teleporter,
+						RegistryAddress:   registry,
+					},
+					"no-rpc": {},
+				},
+			},
+		},
+	}
+	defer func() {
+		cliConfig = nil
+		verifyContract = messengerContract
+		verifyAddress = ""
+		verifyRPCs = nil
+		verifyChains = nil
+	}()
+
+	verifyContract = messengerContract
+	verifyChains = []string{"c-chain"}
+	targets, err := verifyTargets()
+	require.NoError(t, err)
+	require.Equal(t, []verifyTarget{{
+		name:    "fuji/c-chain",
+		rpcURL:  "https://api.avax-test.network/ext/bc/C/rpc",
+		address: teleporter,
+	}}, targets)
+
+	verifyContract = registryContract
+	targets, err = verifyTargets()
+	require.NoError(t, err)
+	require.Equal(t, registry, targets[0].address)
+
+	verifyAddress = "0x0000000000000000000000000000000000000001"
+	verifyRPCs = []string{"http://127.0.0.1:9650/ext/bc/C/rpc"}
+	targets, err = verifyTargets()
+	require.NoError(t, err)
+	require.Len(t, targets, 2)
+	require.Equal(t, "http://127.0.0.1:9650/ext/bc/C/rpc", targets[0].name)
+	for _, target := range targets {
+		require.Equal(t, common.HexToAddress("0x01"), target.address)
+	}
+
+	verifyChains = []string{"no-rpc"}
+	_, err = verifyTargets()
+	require.ErrorContains(t, err, "chain fuji/no-rpc has no rpcURL")
+
+	verifyAddress = ""
+	verifyChains = nil
+	_, err = verifyTargets()
+	require.ErrorContains(t, err, "--address is required with --rpc")
+
+	verifyRPCs = nil
+	_, err = verifyTargets()
+	require.ErrorContains(t, err, "no chains to verify")
+}
@@ -0,0 +1,134 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	teleporterregistry "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/upgrades/TeleporterRegistry"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// warpOutput is a decoded Warp message. The signature is only set for signed messages,
+// and at most one of the payload fields is set depending on the payload type.
+type warpOutput struct {
+	MessageID          ids.ID                                 `json:"messageID"`
+	NetworkID          uint32                                 `json:"networkID"`
+	SourceBlockchainID ids.ID                                 `json:"sourceBlockchainID"`
+	Signature          *warpSignatureOutput                   `json:"signature,omitempty"`
+	AddressedCall      *warpPayload.AddressedCall             `json:"addressedCall,omitempty"`
+	Hash               *warpPayload.Hash                      `json:"hash,omitempty"`
+	TeleporterMessage  *teleportermessenger.TeleporterMessage `json:"teleporterMessage,omitempty"`
+	RegistryEntry      *registryEntryOutput                   `json:"registryEntry,omitempty"`
+}
+
+// warpSignatureOutput is a BLS bit-set signature along with the indices of the signing validators
+type warpSignatureOutput struct {
+	Signers    []int  `json:"signers"`
+	NumSigners int    `json:"numSigners"`
+	Signature  []byte `json:"signature"`
+}
+
+// registryEntryOutput is a TeleporterRegistry Warp payload registering a new protocol version
+type registryEntryOutput struct {
+	Entry              teleporterregistry.ProtocolRegistryEntry `json:"entry"`
+	DestinationAddress common.Address                           `json:"destinationAddress"`
+}
+
+var warpCmd = &cobra.Command{
+	Use:   "warp",
+	Short: "Commands for inspecting Warp messages",
+	Long:  `Commands for inspecting Warp messages carrying Teleporter and TeleporterRegistry payloads.`,
+	Args:  cobra.NoArgs,
+}
+
+var warpDecodeCmd = &cobra.Command{
+	Use:   "decode MESSAGE_BYTES",
+	Short: "Decodes hex encoded signed or unsigned Warp message bytes",
+	Long: `Given the hex encoded bytes of a signed or unsigned Warp message, this command
+will decode the network ID, source blockchain ID, and payload of the message. For
+signed messages the BLS bit-set signers and aggregate signature are also shown. If
+the payload is an AddressedCall carrying a Teleporter message or a TeleporterRegistry
+payload, the payload is decoded as well.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		b, err := parseHexBytes(args[0])
+		cobra.CheckErr(err)
+
+		out, err := decodeWarpMessage(b)
+		cobra.CheckErr(err)
+		cobra.CheckErr(printOutput(cmd, out))
+		cmd.Println("Warp decode command ran successfully")
+	},
+}
+
+func decodeWarpMessage(b []byte) (*warpOutput, error) {
+	var (
+		unsignedMsg *avalancheWarp.UnsignedMessage
+		out         = &warpOutput{}
+	)
+	if signedMsg, err := avalancheWarp.ParseMessage(b); err == nil {
+		unsignedMsg = &signedMsg.UnsignedMessage
+		signature, ok := signedMsg.Signature.(*avalancheWarp.BitSetSignature)
+		if !ok {
+			return nil, fmt.Errorf("unsupported signature type %T", signedMsg.Signature)
+		}
+		signers := set.BitsFromBytes(signature.Signers)
+		out.Signature = &warpSignatureOutput{
+			Signers:    []int{},
+			NumSigners: signers.Len(),
+			Signature:  signature.Signature[:],
+		}
+		for i := 0; i < signers.BitLen(); i++ {
+			if signers.Contains(i) {
+				out.Signature.Signers = append(out.Signature.Signers, i)
+			}
+		}
+	} else {
+		logger.Debug("Failed to parse signed Warp message, trying unsigned", zap.Error(err))
+		unsignedMsg, err = avalancheWarp.ParseUnsignedMessage(b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse signed or unsigned Warp message: %w", err)
+		}
+	}
+	out.MessageID = unsignedMsg.ID()
+	out.NetworkID = unsignedMsg.NetworkID
+	out.SourceBlockchainID = unsignedMsg.SourceChainID
+
+	parsedPayload, err := warpPayload.Parse(unsignedMsg.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Warp payload: %w", err)
+	}
+	switch p := parsedPayload.(type) {
+	case *warpPayload.Hash:
+		out.Hash = p
+	case *warpPayload.AddressedCall:
+		out.AddressedCall = p
+		if message, err := teleportermessenger.UnpackTeleporterMessage(p.Payload); err == nil {
+			out.TeleporterMessage = message
+		} else if entry, destination, err := teleporterregistry.UnpackTeleporterRegistryWarpPayload(
+			p.Payload,
+		); err == nil {
+			out.RegistryEntry = &registryEntryOutput{
+				Entry:              entry,
+				DestinationAddress: destination,
+			}
+		} else {
+			logger.Debug("AddressedCall payload is not a Teleporter or TeleporterRegistry payload")
+		}
+	}
+	return out, nil
+}
+
+func init() {
+	rootCmd.AddCommand(warpCmd)
+	warpCmd.AddCommand(warpDecodeCmd)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	teleporterregistry "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/upgrades/TeleporterRegistry"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarpDecodeCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"warp", "decode"},
+			err:  fmt.Errorf("accepts 1 arg(s), received 0"),
+		},
+		{
+			name: "help",
+			args: []string{"warp", "decode", "--help"},
+			err:  nil,
+			out:  "Given the hex encoded bytes of a signed or unsigned Warp message",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestDecodeWarpMessage(t *testing.T) {
+	logger = logging.NoLog{}
+	sourceBlockchainID := ids.GenerateTestID()
+	sourceAddress := common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+
+	teleporterMessage := teleportermessenger.TeleporterMessage{
+		MessageNonce:            big.NewInt(1),
+		DestinationBlockchainID: ids.GenerateTestID(),
+		RequiredGasLimit:        big.NewInt(2),
+		AllowedRelayerAddresses: []common.Address{},
+		Receipts:                []teleportermessenger.TeleporterMessageReceipt{},
+		Message:                 []byte{1, 2, 3, 4},
+	}
+	teleporterMessageBytes, err := teleportermessenger.PackTeleporterMessage(teleporterMessage)
+	require.NoError(t, err)
+
+	registryEntry := teleporterregistry.ProtocolRegistryEntry{
+		Version:         big.NewInt(2),
+		ProtocolAddress: sourceAddress,
+	}
+	registryPayloadBytes, err := teleporterregistry.PackTeleporterRegistryWarpPayload(registryEntry, sourceAddress)
+	require.NoError(t, err)
+
+	newUnsignedMessage := func(payload []byte) *avalancheWarp.UnsignedMessage {
+		addressedCall, err := warpPayload.NewAddressedCall(sourceAddress.Bytes(), payload)
+		require.NoError(t, err)
+		unsignedMessage, err := avalancheWarp.NewUnsignedMessage(5, sourceBlockchainID, addressedCall.Bytes())
+		require.NoError(t, err)
+		return unsignedMessage
+	}
+
+	t.Run("unsigned Teleporter message", func(t *testing.T) {
+		unsignedMessage := newUnsignedMessage(teleporterMessageBytes)
+		out, err := decodeWarpMessage(unsignedMessage.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, unsignedMessage.ID(), out.MessageID)
+		require.Equal(t, uint32(5), out.NetworkID)
+		require.Equal(t, sourceBlockchainID, out.SourceBlockchainID)
+		require.Nil(t, out.Signature)
+		require.Equal(t, sourceAddress.Bytes(), out.AddressedCall.SourceAddress)
+		require.Equal(t, teleporterMessage, *out.TeleporterMessage)
+		require.Nil(t, out.RegistryEntry)
+	})
+
+	t.Run("signed registry payload", func(t *testing.T) {
+		unsignedMessage := newUnsignedMessage(registryPayloadBytes)
+		signature := &avalancheWarp.BitSetSignature{
+			Signers:   set.NewBits(0, 3).Bytes(),
+			Signature: [96]byte{1},
+		}
+		signedMessage, err := avalancheWarp.NewMessage(unsignedMessage, signature)
+		require.NoError(t, err)
+
+		out, err := decodeWarpMessage(signedMessage.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, []int{0, 3}, out.Signature.Signers)
+		require.Equal(t, 2, out.Signature.NumSigners)
+		require.Equal(t, signature.Signature[:], out.Signature.Signature)
+		require.Equal(t, registryEntry.Version, out.RegistryEntry.Entry.Version)
+		require.Equal(t, sourceAddress, out.RegistryEntry.DestinationAddress)
+	})
+
+	t.Run("invalid bytes", func(t *testing.T) {
+		_, err := decodeWarpMessage([]byte{1, 2, 3})
+		require.ErrorContains(t, err, "failed to parse signed or unsigned Warp message")
+	})
+}
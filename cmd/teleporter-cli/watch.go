@@ -0,0 +1,253 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const sendWarpMessageEventName = "SendWarpMessage"
+
+var (
+	watchWSEndpoint              string
+	watchEvents                  []string
+	watchDestinationBlockchainID string
+	watchSender                  string
+)
+
+// watchEventOutput is a Teleporter event or Teleporter Warp message observed by the watch command
+type watchEventOutput struct {
+	BlockNumber uint64             `json:"blockNumber"`
+	TxHash      common.Hash        `json:"txHash"`
+	Name        string             `json:"name"`
+	Event       interface{}        `json:"event,omitempty"`
+	WarpMessage *warpMessageOutput `json:"warpMessage,omitempty"`
+}
+
+// watchFilter selects the events printed by the watch command. Unset fields match all events.
+type watchFilter struct {
+	events                  map[teleportermessenger.Event]struct{}
+	destinationBlockchainID *ids.ID
+	sender                  *common.Address
+}
+
+var watchCmd = &cobra.Command{
+	Use:   "watch --ws WS_URL --teleporter-address CONTRACT_ADDRESS",
+	Short: "Streams Teleporter events as they are emitted",
+	Long: `Subscribes over WebSocket to the logs of the Teleporter contract and the Warp
+precompile, and prints each Teleporter event and Teleporter Warp message as it is
+accepted until interrupted. Events can be filtered by type with --event, which may
+be repeated. Warp messages are printed along with SendCrossChainMessage events.
+--destination-blockchain-id and --sender filter by the destination blockchain and
+origin sender of the message an event carries, and exclude events that carry
+neither.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		filter, err := parseWatchFilter()
+		cobra.CheckErr(err)
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		cobra.CheckErr(watchTeleporterEvents(ctx, cmd, filter))
+		cmd.Println("Watch command ran successfully")
+	},
+}
+
+func parseWatchFilter() (*watchFilter, error) {
+	filter := &watchFilter{}
+	if len(watchEvents) > 0 {
+		filter.events = make(map[teleportermessenger.Event]struct{})
+		for _, name := range watchEvents {
+			event, err := teleportermessenger.ToEvent(name)
+			if err != nil {
+				return nil, err
+			}
+			filter.events[event] = struct{}{}
+		}
+	}
+	if watchDestinationBlockchainID != "" {
+		destinationBlockchainID, err := parseID(watchDestinationBlockchainID)
+		if err != nil {
+			return nil, err
+		}
+		filter.destinationBlockchainID = &destinationBlockchainID
+	}
+	if watchSender != "" {
+		sender, err := parseAddress(watchSender)
+		if err != nil {
+			return nil, err
+		}
+		filter.sender = &sender
+	}
+	return filter, nil
+}
+
+func watchTeleporterEvents(ctx context.Context, cmd *cobra.Command, filter *watchFilter) error {
+	wsClient, err := ethclient.DialContext(ctx, watchWSEndpoint)
+	if err != nil {
+		return err
+	}
+	defer wsClient.Close()
+
+	logs := make(chan types.Log)
+	sub, err := wsClient.SubscribeFilterLogs(ctx, interfaces.FilterQuery{
+		Addresses: []common.Address{teleporterAddress, common.HexToAddress(warpPrecompileAddress)},
+	}, logs)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+	logger.Info("Watching for Teleporter events", zap.String("teleporterAddress", teleporterAddress.Hex()))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-sub.Err():
+			return err
+		case log := <-logs:
+			out, err := decodeWatchedLog(log)
+			if err != nil {
+				logger.Warn("Failed to decode log",
+					zap.String("txHash", log.TxHash.Hex()),
+					zap.Uint("logIndex", log.Index),
+					zap.Error(err))
+				continue
+			}
+			if out == nil || !filter.matches(out) {
+				continue
+			}
+			if err := printOutput(cmd, out); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// decodeWatchedLog decodes a Teleporter log, or a Warp log sent by the Teleporter contract.
+// Returns nil for Warp logs sent by other contracts.
+func decodeWatchedLog(log types.Log) (*watchEventOutput, error) {
+	out := &watchEventOutput{
+		BlockNumber: log.BlockNumber,
+		TxHash:      log.TxHash,
+	}
+	if log.Address == common.HexToAddress(warpPrecompileAddress) {
+		// The first indexed topic of a SendWarpMessage log is the address of the sender.
+		if len(log.Topics) < 2 || common.BytesToAddress(log.Topics[1].Bytes()) != teleporterAddress {
+			return nil, nil
+		}
+		warpMessage, err := decodeWarpLog(log.Data)
+		if err != nil {
+			return nil, err
+		}
+		out.Name = sendWarpMessageEventName
+		out.WarpMessage = warpMessage
+		return out, nil
+	}
+
+	event, err := teleporterABI.EventByID(log.Topics[0])
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := teleportermessenger.FilterTeleporterEvents(log.Topics, log.Data, event.Name)
+	if err != nil {
+		return nil, err
+	}
+	out.Name = event.Name
+	out.Event = parsed
+	return out, nil
+}
+
+func (f *watchFilter) matches(out *watchEventOutput) bool {
+	if f.events != nil {
+		event := teleportermessenger.SendCrossChainMessage
+		if out.WarpMessage == nil {
+			var err error
+			if event, err = teleportermessenger.ToEvent(out.Name); err != nil {
+				return false
+			}
+		}
+		if _, ok := f.events[event]; !ok {
+			return false
+		}
+	}
+	if f.destinationBlockchainID == nil && f.sender == nil {
+		return true
+	}
+
+	destinationBlockchainID, sender, ok := watchedMessageFields(out)
+	if !ok {
+		return false
+	}
+	if f.destinationBlockchainID != nil && destinationBlockchainID != *f.destinationBlockchainID {
+		return false
+	}
+	if f.sender != nil && (sender == nil || *sender != *f.sender) {
+		return false
+	}
+	return true
+}
+
+// watchedMessageFields returns the destination blockchain ID and, if known, the origin sender of the
+// message an event carries. Returns false if the event does not identify a message destination.
+func watchedMessageFields(out *watchEventOutput) (ids.ID, *common.Address, bool) {
+	if out.WarpMessage != nil {
+		return out.WarpMessage.Message.DestinationBlockchainID, &out.WarpMessage.Message.OriginSenderAddress, true
+	}
+	switch event := out.Event.(type) {
+	case *teleportermessenger.TeleporterMessengerSendCrossChainMessage:
+		return event.DestinationBlockchainID, &event.Message.OriginSenderAddress, true
+	case *teleportermessenger.TeleporterMessengerReceiveCrossChainMessage:
+		return event.Message.DestinationBlockchainID, &event.Message.OriginSenderAddress, true
+	case *teleportermessenger.TeleporterMessengerMessageExecutionFailed:
+		return event.Message.DestinationBlockchainID, &event.Message.OriginSenderAddress, true
+	case *teleportermessenger.TeleporterMessengerReceiptReceived:
+		return event.DestinationBlockchainID, nil, true
+	default:
+		return ids.ID{}, nil, false
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+	watchCmd.Flags().StringVar(&watchWSEndpoint, "ws", "", "WebSocket endpoint of the chain to watch")
+	address := watchCmd.PersistentFlags().StringP(teleporterAddressFlag, "t", "", "Teleporter contract address")
+	watchCmd.Flags().StringSliceVar(&watchEvents, "event", nil,
+		"Teleporter event type to print, may be repeated. Prints all events if not provided")
+	watchCmd.Flags().StringVar(&watchDestinationBlockchainID, "destination-blockchain-id", "",
+		"Only print events for messages to this destination blockchain")
+	watchCmd.Flags().StringVar(&watchSender, "sender", "",
+		"Only print events for messages sent by this origin sender address")
+	cobra.CheckErr(watchCmd.MarkFlagRequired("ws"))
+	cobra.CheckErr(watchCmd.MarkPersistentFlagRequired(teleporterAddressFlag))
+	watchCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := callPersistentPreRunE(cmd, args); err != nil {
+			return err
+		}
+		if selectedChain != nil {
+			if err := setFlagDefault(cmd, "ws", selectedChain.WSURL); err != nil {
+				return err
+			}
+		}
+		if err := setTeleporterAddressDefault(cmd, selectedChain); err != nil {
+			return err
+		}
+		if err := cmd.ValidateRequiredFlags(); err != nil {
+			return err
+		}
+		teleporterAddress = common.HexToAddress(*address)
+		return nil
+	}
+}
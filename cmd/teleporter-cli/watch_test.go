@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"watch"},
+			err:  fmt.Errorf("required flag(s) \"teleporter-address\", \"ws\" not set"),
+		},
+		{
+			name: "help",
+			args: []string{"watch", "--help"},
+			err:  nil,
+			out:  "Subscribes over WebSocket to the logs of the Teleporter contract and the Warp",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestWatchFilterMatches(t *testing.T) {
+	destinationBlockchainID := ids.GenerateTestID()
+	sender := common.HexToAddress("0x1234")
+	message := teleportermessenger.TeleporterMessage{
+		DestinationBlockchainID: destinationBlockchainID,
+		OriginSenderAddress:     sender,
+	}
+	sendEvent := &watchEventOutput{
+		Name: "SendCrossChainMessage",
+		Event: &teleportermessenger.TeleporterMessengerSendCrossChainMessage{
+			DestinationBlockchainID: destinationBlockchainID,
+			Message:                 message,
+		},
+	}
+	warpEvent := &watchEventOutput{
+		Name:        sendWarpMessageEventName,
+		WarpMessage: &warpMessageOutput{Message: &message},
+	}
+	receiptEvent := &watchEventOutput{
+		Name: "ReceiptReceived",
+		Event: &teleportermessenger.TeleporterMessengerReceiptReceived{
+			DestinationBlockchainID: destinationBlockchainID,
+		},
+	}
+	executedEvent := &watchEventOutput{
+		Name:  "MessageExecuted",
+		Event: &teleportermessenger.TeleporterMessengerMessageExecuted{},
+	}
+	otherBlockchainID := ids.GenerateTestID()
+	otherSender := common.HexToAddress("0x5678")
+
+	var tests = []struct {
+		name     string
+		filter   watchFilter
+		event    *watchEventOutput
+		expected bool
+	}{
+		{
+			name:     "no filter",
+			filter:   watchFilter{},
+			event:    executedEvent,
+			expected: true,
+		},
+		{
+			name: "event type match",
+			filter: watchFilter{
+				events: map[teleportermessenger.Event]struct{}{teleportermessenger.ReceiptReceived: {}},
+			},
+			event:    receiptEvent,
+			expected: true,
+		},
+		{
+			name: "event type mismatch",
+			filter: watchFilter{
+				events: map[teleportermessenger.Event]struct{}{teleportermessenger.ReceiptReceived: {}},
+			},
+			event:    sendEvent,
+			expected: false,
+		},
+		{
+			name: "warp message with send event type",
+			filter: watchFilter{
+				events: map[teleportermessenger.Event]struct{}{teleportermessenger.SendCrossChainMessage: {}},
+			},
+			event:    warpEvent,
+			expected: true,
+		},
+		{
+			name:     "destination match",
+			filter:   watchFilter{destinationBlockchainID: &destinationBlockchainID},
+			event:    receiptEvent,
+			expected: true,
+		},
+		{
+			name:     "destination mismatch",
+			filter:   watchFilter{destinationBlockchainID: &otherBlockchainID},
+			event:    warpEvent,
+			expected: false,
+		},
+		{
+			name:     "destination on event without message",
+			filter:   watchFilter{destinationBlockchainID: &destinationBlockchainID},
+			event:    executedEvent,
+			expected: false,
+		},
+		{
+			name:     "sender match",
+			filter:   watchFilter{sender: &sender},
+			event:    sendEvent,
+			expected: true,
+		},
+		{
+			name:     "sender mismatch",
+			filter:   watchFilter{sender: &otherSender},
+			event:    sendEvent,
+			expected: false,
+		},
+		{
+			name:     "sender on event without sender",
+			filter:   watchFilter{sender: &sender},
+			event:    receiptEvent,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.filter.matches(tt.event))
+		})
+	}
+}
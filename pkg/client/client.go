@@ -0,0 +1,405 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package client is a Go client for the TeleporterMessenger contract on a single chain. It sends
+// Teleporter messages and the other TeleporterMessenger transactions, waits for them to be accepted,
+// and queries the state of messages, returning errors rather than panicking.
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	erc20utils "github.com/ava-labs/teleporter/utils/erc20-utils"
+	teleporterutils "github.com/ava-labs/teleporter/utils/teleporter-utils"
+	transactionutils "github.com/ava-labs/teleporter/utils/transaction-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// ErrNoSigner is returned when sending a transaction with a client that has no signer
+	ErrNoSigner = errors.New("client has no signer")
+	// ErrTransactionFailed is returned when a transaction is accepted but reverted
+	ErrTransactionFailed = transactionutils.ErrTransactionFailed
+)
+
+// Backend is the connection to a chain used by a Client, such as an ethclient.Client
+type Backend interface {
+	bind.ContractBackend
+	transactionutils.Backend
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+// Signer signs the transactions sent by a Client
+type Signer interface {
+	// Address returns the address transactions are sent from
+	Address() common.Address
+	// SignTx returns the transaction signed for the given chain
+	SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// NewKeySigner returns a Signer that signs transactions with the given private key
+func NewKeySigner(key *ecdsa.PrivateKey) Signer {
+	return &keySigner{key: key}
+}
+
+type keySigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s *keySigner) Address() common.Address {
+	return crypto.PubkeyToAddress(s.key.PublicKey)
+}
+
+func (s *keySigner) SignTx(_ context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key)
+}
+
+// TxOption configures a transaction sent by a Client
+type TxOption func(*txConfig)
+
+type txConfig struct {
+	gasLimit uint64
+}
+
+// WithGasLimit sets the gas limit of the transaction. The gas limit is estimated if it is zero,
+// which is the default.
+func WithGasLimit(gasLimit uint64) TxOption {
+	return func(c *txConfig) {
+		c.gasLimit = gasLimit
+	}
+}
+
+// Client sends transactions to and queries the TeleporterMessenger contract on a single chain
+type Client struct {
+	backend           Backend
+	signer            Signer
+	chainID           *big.Int
+	teleporterAddress common.Address
+	messenger         *teleportermessenger.TeleporterMessenger
+	// Closes the backend if it was dialed by the client
+	close func()
+}
+
+// New returns a client for the TeleporterMessenger contract at the given address. The signer may be
+// nil, in which case only queries can be made.
+func New(ctx context.Context, backend Backend, teleporterAddress common.Address, signer Signer) (*Client, error) {
+	chainID, err := backend.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	messenger, err := teleportermessenger.NewTeleporterMessenger(teleporterAddress, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		backend:           backend,
+		signer:            signer,
+		chainID:           chainID,
+		teleporterAddress: teleporterAddress,
+		messenger:         messenger,
+		close:             func() {},
+	}, nil
+}
+
+// Dial connects to the chain at the given RPC endpoint, and returns a client for the
+// TeleporterMessenger contract at the given address. The client must be closed when no longer used.
+func Dial(ctx context.Context, rpcURL string, teleporterAddress common.Address, signer Signer) (*Client, error) {
+	ethClient, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", rpcURL, err)
+	}
+	c, err := New(ctx, ethClient, teleporterAddress, signer)
+	if err != nil {
+		ethClient.Close()
+		return nil, err
+	}
+	c.close = ethClient.Close
+	return c, nil
+}
+
+// Close closes the connection to the chain if it was opened by Dial
+func (c *Client) Close() {
+	c.close()
+}
+
+// ChainID returns the EVM chain ID of the chain
+func (c *Client) ChainID() *big.Int {
+	return new(big.Int).Set(c.chainID)
+}
+
+// TeleporterAddress returns the address of the TeleporterMessenger contract
+func (c *Client) TeleporterAddress() common.Address {
+	return c.teleporterAddress
+}
+
+// Messenger returns the TeleporterMessenger binding used by the client
+func (c *Client) Messenger() *teleportermessenger.TeleporterMessenger {
+	return c.messenger
+}
+
+// BlockchainID returns the blockchain ID of the chain, as stored by the TeleporterMessenger contract.
+// The blockchain ID is empty until the contract sends or receives its first message.
+func (c *Client) BlockchainID(ctx context.Context) (ids.ID, error) {
+	return c.messenger.BlockchainID(&bind.CallOpts{Context: ctx})
+}
+
+// CalculateMessageID returns the ID of the message sent from the source chain to the destination chain
+// with the given nonce
+func (c *Client) CalculateMessageID(
+	sourceBlockchainID ids.ID,
+	destinationBlockchainID ids.ID,
+	nonce *big.Int,
+) (ids.ID, error) {
+	return teleporterutils.CalculateMessageID(c.teleporterAddress, sourceBlockchainID, destinationBlockchainID, nonce)
+}
+
+// Send sends a Teleporter message, approving the fee amount to be spent by the TeleporterMessenger
+// contract if needed. Returns the ID of the message and the receipt of the transaction sending it.
+func (c *Client) Send(
+	ctx context.Context,
+	input teleportermessenger.TeleporterMessageInput,
+	opts ...TxOption,
+) (ids.ID, *types.Receipt, error) {
+	if input.FeeInfo.Amount == nil {
+		input.FeeInfo.Amount = big.NewInt(0)
+	}
+	if input.AllowedRelayerAddresses == nil {
+		input.AllowedRelayerAddresses = []common.Address{}
+	}
+	if _, err := c.EnsureAllowance(ctx, input.FeeInfo.FeeTokenAddress, input.FeeInfo.Amount); err != nil {
+		return ids.ID{}, nil, err
+	}
+	receipt, err := c.transact(ctx, c.teleporterAddress, opts, func() ([]byte, error) {
+		return teleportermessenger.PackSendCrossChainMessage(input)
+	})
+	if err != nil {
+		return ids.ID{}, receipt, err
+	}
+	messageID, err := c.sentMessageID(receipt)
+	return messageID, receipt, err
+}
+
+// AddFee adds to the fee of a message sent from this chain, approving the amount to be spent by the
+// TeleporterMessenger contract if needed
+func (c *Client) AddFee(
+	ctx context.Context,
+	messageID ids.ID,
+	feeTokenAddress common.Address,
+	amount *big.Int,
+	opts ...TxOption,
+) (*types.Receipt, error) {
+	if _, err := c.EnsureAllowance(ctx, feeTokenAddress, amount); err != nil {
+		return nil, err
+	}
+	return c.transact(ctx, c.teleporterAddress, opts, func() ([]byte, error) {
+		return teleportermessenger.PackAddFeeAmount(messageID, feeTokenAddress, amount)
+	})
+}
+
+// RetryExecution retries the execution of a message received by this chain whose execution failed.
+// The message is checked with VerifyFailedMessage before the transaction is sent.
+func (c *Client) RetryExecution(
+	ctx context.Context,
+	sourceBlockchainID ids.ID,
+	message teleportermessenger.TeleporterMessage,
+	opts ...TxOption,
+) (*types.Receipt, error) {
+	if err := c.VerifyFailedMessage(ctx, sourceBlockchainID, message); err != nil {
+		return nil, err
+	}
+	return c.transact(ctx, c.teleporterAddress, opts, func() ([]byte, error) {
+		return teleportermessenger.PackRetryMessageExecution(sourceBlockchainID, message)
+	})
+}
+
+// RetrySend resends a message sent from this chain that has not been delivered. The message is checked
+// with VerifySentMessage before the transaction is sent.
+func (c *Client) RetrySend(
+	ctx context.Context,
+	message teleportermessenger.TeleporterMessage,
+	opts ...TxOption,
+) (*types.Receipt, error) {
+	if err := c.VerifySentMessage(ctx, message); err != nil {
+		return nil, err
+	}
+	return c.transact(ctx, c.teleporterAddress, opts, func() ([]byte, error) {
+		return teleportermessenger.PackRetrySendCrossChainMessage(message)
+	})
+}
+
+// VerifySentMessage checks that a message sent from this chain, such as one reconstructed from its
+// SendCrossChainMessage event, matches the message hash stored by the contract. Returns an error
+// wrapping teleporterutils.ErrMessageHashMismatch if the message was altered, or
+// teleporterutils.ErrMessageHashNotFound if the message cannot be resent.
+func (c *Client) VerifySentMessage(ctx context.Context, message teleportermessenger.TeleporterMessage) error {
+	blockchainID, err := c.BlockchainID(ctx)
+	if err != nil {
+		return err
+	}
+	messageID, err := c.CalculateMessageID(blockchainID, message.DestinationBlockchainID, message.MessageNonce)
+	if err != nil {
+		return err
+	}
+	return teleporterutils.VerifySentMessage(
+		&bind.CallOpts{Context: ctx},
+		&c.messenger.TeleporterMessengerCaller,
+		messageID,
+		message,
+	)
+}
+
+// VerifyFailedMessage checks that a message received by this chain, such as one reconstructed from its
+// MessageExecutionFailed event, matches the hash stored by the contract for its failed execution.
+// Returns an error wrapping teleporterutils.ErrMessageHashMismatch if the message was altered, or
+// teleporterutils.ErrMessageHashNotFound if the message has no failed execution to retry.
+func (c *Client) VerifyFailedMessage(
+	ctx context.Context,
+	sourceBlockchainID ids.ID,
+	message teleportermessenger.TeleporterMessage,
+) error {
+	messageID, err := c.CalculateMessageID(sourceBlockchainID, message.DestinationBlockchainID, message.MessageNonce)
+	if err != nil {
+		return err
+	}
+	return teleporterutils.VerifyFailedMessage(
+		&bind.CallOpts{Context: ctx},
+		&c.messenger.TeleporterMessengerCaller,
+		messageID,
+		message,
+	)
+}
+
+// SendSpecifiedReceipts sends the receipts of the given messages received by this chain back to their
+// source chain, approving the fee amount to be spent by the TeleporterMessenger contract if needed.
+// Returns the ID of the message containing the receipts and the receipt of the transaction sending it.
+func (c *Client) SendSpecifiedReceipts(
+	ctx context.Context,
+	sourceBlockchainID ids.ID,
+	messageIDs []ids.ID,
+	feeInfo teleportermessenger.TeleporterFeeInfo,
+	allowedRelayerAddresses []common.Address,
+	opts ...TxOption,
+) (ids.ID, *types.Receipt, error) {
+	if feeInfo.Amount == nil {
+		feeInfo.Amount = big.NewInt(0)
+	}
+	if allowedRelayerAddresses == nil {
+		allowedRelayerAddresses = []common.Address{}
+	}
+	if _, err := c.EnsureAllowance(ctx, feeInfo.FeeTokenAddress, feeInfo.Amount); err != nil {
+		return ids.ID{}, nil, err
+	}
+	receiptIDs := make([][32]byte, len(messageIDs))
+	for i, messageID := range messageIDs {
+		receiptIDs[i] = messageID
+	}
+	receipt, err := c.transact(ctx, c.teleporterAddress, opts, func() ([]byte, error) {
+		return teleportermessenger.PackSendSpecifiedReceipts(sourceBlockchainID, receiptIDs, feeInfo,
+			allowedRelayerAddresses)
+	})
+	if err != nil {
+		return ids.ID{}, receipt, err
+	}
+	messageID, err := c.sentMessageID(receipt)
+	return messageID, receipt, err
+}
+
+// RedeemRewards redeems the relayer rewards in the given fee token earned by the signer's address
+func (c *Client) RedeemRewards(
+	ctx context.Context,
+	feeTokenAddress common.Address,
+	opts ...TxOption,
+) (*types.Receipt, error) {
+	return c.transact(ctx, c.teleporterAddress, opts, func() ([]byte, error) {
+		return teleportermessenger.PackRedeemRelayerRewards(feeTokenAddress)
+	})
+}
+
+// transact sends a transaction calling the given contract with the call data returned by pack, signed by the
+// client's signer, and waits for it to be accepted. Returns ErrTransactionFailed along with the receipt if the
+// transaction was reverted.
+func (c *Client) transact(
+	ctx context.Context,
+	to common.Address,
+	opts []TxOption,
+	pack func() ([]byte, error),
+) (*types.Receipt, error) {
+	if c.signer == nil {
+		return nil, ErrNoSigner
+	}
+	data, err := pack()
+	if err != nil {
+		return nil, err
+	}
+	var config txConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	tx, err := transactionutils.NewTransaction(
+		ctx, c.backend, c.chainID, c.signer.Address(), &to, config.gasLimit, nil, data,
+	)
+	if err != nil {
+		return nil, err
+	}
+	signedTx, err := c.signer.SignTx(ctx, tx, c.chainID)
+	if err != nil {
+		return nil, err
+	}
+	return transactionutils.SendTransactionAndWaitForSuccess(ctx, logging.NoLog{}, c.backend, signedTx)
+}
+
+// EnsureAllowance approves the TeleporterMessenger contract to spend the given amount of the fee token
+// from the signer's address, if the current allowance is insufficient. Returns the receipt of the
+// approval transaction, or nil if no approval was needed. The methods paying a fee call EnsureAllowance
+// themselves.
+func (c *Client) EnsureAllowance(
+	ctx context.Context,
+	feeTokenAddress common.Address,
+	amount *big.Int,
+) (*types.Receipt, error) {
+	if amount.Sign() == 0 {
+		return nil, nil
+	}
+	if c.signer == nil {
+		return nil, ErrNoSigner
+	}
+	token := erc20utils.NewERC20(feeTokenAddress, c.backend)
+	allowance, err := erc20utils.Allowance(&bind.CallOpts{Context: ctx}, token, c.signer.Address(), c.teleporterAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fee token allowance: %w", err)
+	}
+	if allowance.Cmp(amount) >= 0 {
+		return nil, nil
+	}
+	receipt, err := c.transact(ctx, feeTokenAddress, nil, func() ([]byte, error) {
+		return erc20utils.PackApprove(c.teleporterAddress, amount)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to approve fee token: %w", err)
+	}
+	return receipt, nil
+}
+
+// sentMessageID returns the ID of the message sent in the transaction with the given receipt
+func (c *Client) sentMessageID(receipt *types.Receipt) (ids.ID, error) {
+	for _, log := range receipt.Logs {
+		if log.Address != c.teleporterAddress {
+			continue
+		}
+		if event, err := c.messenger.ParseSendCrossChainMessage(*log); err == nil {
+			return event.MessageID, nil
+		}
+	}
+	return ids.ID{}, fmt.Errorf("no SendCrossChainMessage event in transaction %s", receipt.TxHash.Hex())
+}
@@ -0,0 +1,184 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind/backends"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/types"
+	exampleerc20 "github.com/ava-labs/teleporter/abi-bindings/go/Mocks/ExampleERC20"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	teleporterutils "github.com/ava-labs/teleporter/utils/teleporter-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// testBackend is a simulated chain that accepts each transaction in its own block
+type testBackend struct {
+	*backends.SimulatedBackend
+}
+
+func (b *testBackend) ChainID(context.Context) (*big.Int, error) {
+	return big.NewInt(1337), nil
+}
+
+func (b *testBackend) BlockNumber(context.Context) (uint64, error) {
+	return b.Blockchain().CurrentBlock().Number.Uint64(), nil
+}
+
+func (b *testBackend) EstimateBaseFee(context.Context) (*big.Int, error) {
+	return b.Blockchain().CurrentBlock().BaseFee, nil
+}
+
+func (b *testBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := b.SimulatedBackend.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	b.Commit(true)
+	return nil
+}
+
+func newTestClient(t *testing.T) (*Client, *testBackend, Signer) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := NewKeySigner(key)
+	backend := &testBackend{backends.NewSimulatedBackend(core.GenesisAlloc{
+		signer.Address(): {Balance: new(big.Int).Lsh(big.NewInt(1), 100)},
+	}, 30_000_000)}
+	t.Cleanup(func() { backend.Close() })
+
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	require.NoError(t, err)
+	teleporterAddress, _, _, err := teleportermessenger.DeployTeleporterMessenger(opts, backend)
+	require.NoError(t, err)
+
+	c, err := New(context.Background(), backend, teleporterAddress, signer)
+	require.NoError(t, err)
+	return c, backend, signer
+}
+
+func TestClientTransactions(t *testing.T) {
+	ctx := context.Background()
+	c, backend, signer := newTestClient(t)
+	require.Equal(t, big.NewInt(1337), c.ChainID())
+
+	// Without rewards to redeem, the transaction is reverted when estimating gas.
+	_, err := c.RedeemRewards(ctx, common.HexToAddress("0x01"))
+	require.ErrorContains(t, err, "no reward to redeem")
+
+	// With a gas limit, the transaction is sent without estimating gas, and is reverted on chain.
+	receipt, err := c.RedeemRewards(ctx, common.HexToAddress("0x01"), WithGasLimit(100_000))
+	require.True(t, errors.Is(err, ErrTransactionFailed))
+	tx, _, err := backend.TransactionByHash(ctx, receipt.TxHash)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100_000), tx.Gas())
+
+	// Adding a fee approves the fee token before the transaction is reverted for the unknown message.
+	opts := &bind.TransactOpts{
+		From: signer.Address(),
+		Signer: func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return signer.SignTx(ctx, tx, c.ChainID())
+		},
+	}
+	feeTokenAddress, _, feeToken, err := exampleerc20.DeployExampleERC20(opts, backend)
+	require.NoError(t, err)
+	_, err = c.AddFee(ctx, ids.GenerateTestID(), feeTokenAddress, big.NewInt(100))
+	require.ErrorContains(t, err, "message not found")
+	allowance, err := feeToken.Allowance(&bind.CallOpts{}, signer.Address(), c.TeleporterAddress())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100), allowance)
+
+	// No approval is sent if the allowance is already sufficient.
+	receipt, err = c.EnsureAllowance(ctx, feeTokenAddress, big.NewInt(100))
+	require.NoError(t, err)
+	require.Nil(t, receipt)
+	receipt, err = c.EnsureAllowance(ctx, feeTokenAddress, big.NewInt(200))
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	allowance, err = feeToken.Allowance(&bind.CallOpts{}, signer.Address(), c.TeleporterAddress())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(200), allowance)
+
+	// Retries are checked against the stored message hash before the transaction is sent.
+	message := teleportermessenger.TeleporterMessage{
+		MessageNonce:            big.NewInt(1),
+		DestinationBlockchainID: ids.GenerateTestID(),
+		RequiredGasLimit:        big.NewInt(1),
+		AllowedRelayerAddresses: []common.Address{},
+		Receipts:                []teleportermessenger.TeleporterMessageReceipt{},
+	}
+	_, err = c.RetrySend(ctx, message)
+	require.True(t, errors.Is(err, teleporterutils.ErrMessageHashNotFound))
+	_, err = c.RetryExecution(ctx, ids.GenerateTestID(), message)
+	require.True(t, errors.Is(err, teleporterutils.ErrMessageHashNotFound))
+
+	// Queries do not need a signer, but transactions do.
+	readOnly, err := New(ctx, backend, c.TeleporterAddress(), nil)
+	require.NoError(t, err)
+	_, err = readOnly.RedeemRewards(ctx, feeTokenAddress)
+	require.True(t, errors.Is(err, ErrNoSigner))
+	_, _, err = readOnly.Send(ctx, teleportermessenger.TeleporterMessageInput{})
+	require.True(t, errors.Is(err, ErrNoSigner))
+}
+
+func TestClientStatus(t *testing.T) {
+	c, _, _ := newTestClient(t)
+
+	status, err := c.Status(context.Background(), ids.GenerateTestID())
+	require.NoError(t, err)
+	require.False(t, status.Received)
+	require.Equal(t, common.Hash{}, status.MessageHash)
+	require.Equal(t, Unknown, Delivery(status, status))
+}
+
+func TestDelivery(t *testing.T) {
+	messageHash := common.HexToHash("0x01")
+	var tests = []struct {
+		name        string
+		source      MessageStatus
+		destination MessageStatus
+		expected    DeliveryStatus
+	}{
+		{
+			name:     "unknown",
+			expected: Unknown,
+		},
+		{
+			name:     "pending",
+			source:   MessageStatus{MessageHash: messageHash},
+			expected: Pending,
+		},
+		{
+			name:        "delivered",
+			source:      MessageStatus{MessageHash: messageHash},
+			destination: MessageStatus{Received: true},
+			expected:    Delivered,
+		},
+		{
+			name:        "delivered-but-execution-failed",
+			source:      MessageStatus{MessageHash: messageHash},
+			destination: MessageStatus{Received: true, FailedMessageHash: messageHash},
+			expected:    ExecutionFailed,
+		},
+		{
+			name:        "receipt-returned",
+			destination: MessageStatus{Received: true},
+			expected:    ReceiptReturned,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, Delivery(&tt.source, &tt.destination))
+			require.Equal(t, tt.name, tt.expected.String())
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DeliveryStatus is the delivery state of a Teleporter message
+type DeliveryStatus uint8
+
+const (
+	Unknown DeliveryStatus = iota
+	Pending
+	Delivered
+	ExecutionFailed
+	ReceiptReturned
+)
+
+// String returns the string representation of a DeliveryStatus
+func (s DeliveryStatus) String() string {
+	switch s {
+	case Pending:
+		return "pending"
+	case Delivered:
+		return "delivered"
+	case ExecutionFailed:
+		return "delivered-but-execution-failed"
+	case ReceiptReturned:
+		return "receipt-returned"
+	default:
+		return "unknown"
+	}
+}
+
+// MessageStatus is the state of a Teleporter message stored by the TeleporterMessenger contract on a
+// single chain. The fee info and message hash are stored by the source chain of the message, and the
+// remaining fields by the destination chain.
+type MessageStatus struct {
+	MessageID ids.ID
+	// Fee of the message, paid to the relayer once the receipt of the message is returned
+	FeeInfo teleportermessenger.TeleporterFeeInfo
+	// Hash of the message, deleted once the receipt of the message is returned
+	MessageHash common.Hash
+	// Whether the message was received from its source chain
+	Received bool
+	// Address the relayer of the message is rewarded at
+	RelayerRewardAddress common.Address
+	// Hash of the message if its execution failed and has not been successfully retried
+	FailedMessageHash common.Hash
+}
+
+// Status queries the state of a message on this chain, which may be its source or destination chain
+func (c *Client) Status(ctx context.Context, messageID ids.ID) (*MessageStatus, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	status := &MessageStatus{MessageID: messageID}
+
+	feeTokenAddress, feeAmount, err := c.messenger.GetFeeInfo(opts, messageID)
+	if err != nil {
+		return nil, err
+	}
+	status.FeeInfo = teleportermessenger.TeleporterFeeInfo{
+		FeeTokenAddress: feeTokenAddress,
+		Amount:          feeAmount,
+	}
+	if status.MessageHash, err = c.messenger.GetMessageHash(opts, messageID); err != nil {
+		return nil, err
+	}
+	if status.Received, err = c.messenger.MessageReceived(opts, messageID); err != nil {
+		return nil, err
+	}
+	// The relayer reward address is only stored for received messages, and querying it otherwise reverts.
+	if status.Received {
+		if status.RelayerRewardAddress, err = c.messenger.GetRelayerRewardAddress(opts, messageID); err != nil {
+			return nil, err
+		}
+	}
+	if status.FailedMessageHash, err = c.messenger.ReceivedFailedMessageHashes(opts, messageID); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// Delivery summarizes the state of a message on its source and destination chains. The source chain
+// deletes the message hash once the receipt for the message is returned, and the destination chain
+// stores the hash of messages whose execution failed until they are successfully retried.
+func Delivery(source *MessageStatus, destination *MessageStatus) DeliveryStatus {
+	switch {
+	case !destination.Received && source.MessageHash == (common.Hash{}):
+		return Unknown
+	case !destination.Received:
+		return Pending
+	case destination.FailedMessageHash != (common.Hash{}):
+		return ExecutionFailed
+	case source.MessageHash == (common.Hash{}):
+		return ReceiptReturned
+	default:
+		return Delivered
+	}
+}
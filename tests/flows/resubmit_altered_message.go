@@ -2,12 +2,14 @@ package flows
 
 import (
 	"context"
+	"errors"
 	"math/big"
 
 	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
 	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
 	"github.com/ava-labs/teleporter/tests/interfaces"
 	"github.com/ava-labs/teleporter/tests/utils"
+	teleporterutils "github.com/ava-labs/teleporter/utils/teleporter-utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	. "github.com/onsi/gomega"
@@ -51,6 +53,15 @@ func ResubmitAlteredMessage(network interfaces.Network) {
 	Expect(event.MessageID[:]).Should(Equal(messageID[:]))
 	teleporterMessage := event.Message
 
+	// The message reconstructed from the event matches the hash stored on the source chain
+	err = teleporterutils.VerifySentMessage(
+		&bind.CallOpts{},
+		&subnetAInfo.TeleporterMessenger.TeleporterMessengerCaller,
+		messageID,
+		teleporterMessage,
+	)
+	Expect(err).Should(BeNil())
+
 	// Alter the message
 	alteredMessage := make([]byte, len(teleporterMessage.Message))
 	copy(alteredMessage, teleporterMessage.Message)
@@ -58,6 +69,15 @@ func ResubmitAlteredMessage(network interfaces.Network) {
 	Expect(alteredMessage[:]).ShouldNot(Equal(teleporterMessage.Message[:]))
 	teleporterMessage.Message = alteredMessage
 
+	// The altered message is detected without sending a transaction
+	err = teleporterutils.VerifySentMessage(
+		&bind.CallOpts{},
+		&subnetAInfo.TeleporterMessenger.TeleporterMessengerCaller,
+		messageID,
+		teleporterMessage,
+	)
+	Expect(errors.Is(err, teleporterutils.ErrMessageHashMismatch)).Should(BeTrue())
+
 	// Resubmit the altered message
 	log.Info("Submitting the altered Teleporter message on the source chain")
 	opts, err := bind.NewKeyedTransactorWithChainID(fundedKey, subnetAInfo.EVMChainID)
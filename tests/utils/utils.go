@@ -7,10 +7,9 @@ import (
 	"bytes"
 	"context"
 	"crypto/ecdsa"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"math/big"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -23,20 +22,17 @@ import (
 	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
 	teleporterregistry "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/upgrades/TeleporterRegistry"
 	deploymentUtils "github.com/ava-labs/teleporter/utils/deployment-utils"
-	gasUtils "github.com/ava-labs/teleporter/utils/gas-utils"
+	transactionUtils "github.com/ava-labs/teleporter/utils/transaction-utils"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
 	"github.com/ava-labs/subnet-evm/accounts/abi"
 	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
 	"github.com/ava-labs/subnet-evm/core/types"
-	"github.com/ava-labs/subnet-evm/eth/tracers"
 	"github.com/ava-labs/subnet-evm/ethclient"
-	subnetEvmInterfaces "github.com/ava-labs/subnet-evm/interfaces"
-	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
-	predicateutils "github.com/ava-labs/subnet-evm/predicate"
 	"github.com/ava-labs/teleporter/tests/interfaces"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -46,10 +42,16 @@ import (
 )
 
 var (
-	NativeTransferGas                   uint64 = 21_000
-	DefaultTeleporterTransactionGas     uint64 = 300_000
+	NativeTransferGas                   uint64 = transactionUtils.NativeTransferGas
+	DefaultTeleporterTransactionGas     uint64 = transactionUtils.DefaultTeleporterTransactionGas
 	DefaultTeleporterTransactionValue          = common.Big0
 	ExpectedExampleERC20DeployerBalance        = new(big.Int).Mul(big.NewInt(1e18), big.NewInt(1e10))
+
+	// Logger passed to the transaction utilities, which logs to the same output as the test logs
+	txLogger = logging.NewLogger(
+		"tests",
+		logging.NewWrappedCore(logging.Info, os.Stdout, logging.Plain.ConsoleEncoder()),
+	)
 )
 
 const (
@@ -235,24 +237,16 @@ func CreateSendCrossChainMessageTransaction(
 	senderKey *ecdsa.PrivateKey,
 	teleporterContractAddress common.Address,
 ) *types.Transaction {
-	data, err := teleportermessenger.PackSendCrossChainMessage(input)
+	tx, err := transactionUtils.CreateSendCrossChainMessageTransaction(
+		ctx,
+		source.RPCClient,
+		source.EVMChainID,
+		input,
+		senderKey,
+		teleporterContractAddress,
+	)
 	Expect(err).Should(BeNil())
-
-	gasFeeCap, gasTipCap, nonce := CalculateTxParams(ctx, source, PrivateKeyToAddress(senderKey))
-
-	// Send a transaction to the Teleporter contract
-	tx := types.NewTx(&types.DynamicFeeTx{
-		ChainID:   source.EVMChainID,
-		Nonce:     nonce,
-		To:        &teleporterContractAddress,
-		Gas:       DefaultTeleporterTransactionGas,
-		GasFeeCap: gasFeeCap,
-		GasTipCap: gasTipCap,
-		Value:     DefaultTeleporterTransactionValue,
-		Data:      data,
-	})
-
-	return SignTransaction(tx, senderKey, source.EVMChainID)
+	return tx
 }
 
 func CreateRetryMessageExecutionTransaction(
@@ -263,28 +257,17 @@ func CreateRetryMessageExecutionTransaction(
 	senderKey *ecdsa.PrivateKey,
 	teleporterContractAddress common.Address,
 ) *types.Transaction {
-	data, err := teleportermessenger.PackRetryMessageExecution(sourceBlockchainID, message)
-	Expect(err).Should(BeNil())
-
-	// TODO: replace with actual number of signers
-	gasLimit, err := gasUtils.CalculateReceiveMessageGasLimit(10, message.RequiredGasLimit)
+	tx, err := transactionUtils.CreateRetryMessageExecutionTransaction(
+		ctx,
+		subnetInfo.RPCClient,
+		subnetInfo.EVMChainID,
+		sourceBlockchainID,
+		message,
+		senderKey,
+		teleporterContractAddress,
+	)
 	Expect(err).Should(BeNil())
-
-	gasFeeCap, gasTipCap, nonce := CalculateTxParams(ctx, subnetInfo, PrivateKeyToAddress(senderKey))
-
-	// Sign a transaction to the Teleporter contract
-	tx := types.NewTx(&types.DynamicFeeTx{
-		ChainID:   subnetInfo.EVMChainID,
-		Nonce:     nonce,
-		To:        &teleporterContractAddress,
-		Gas:       gasLimit,
-		GasFeeCap: gasFeeCap,
-		GasTipCap: gasTipCap,
-		Value:     DefaultTeleporterTransactionValue,
-		Data:      data,
-	})
-
-	return SignTransaction(tx, senderKey, subnetInfo.EVMChainID)
+	return tx
 }
 
 // Constructs a transaction to call receiveCrossChainMessage
@@ -297,34 +280,18 @@ func CreateReceiveCrossChainMessageTransaction(
 	senderKey *ecdsa.PrivateKey,
 	subnetInfo interfaces.SubnetTestInfo,
 ) *types.Transaction {
-	// Construct the transaction to send the Warp message to the destination chain
-	log.Info("Constructing receiveCrossChainMessage transaction for the destination chain")
-	numSigners, err := signedMessage.Signature.NumSigners()
-	Expect(err).Should(BeNil())
-
-	gasLimit, err := gasUtils.CalculateReceiveMessageGasLimit(numSigners, requiredGasLimit)
-	Expect(err).Should(BeNil())
-
-	callData, err := teleportermessenger.PackReceiveCrossChainMessage(0, PrivateKeyToAddress(senderKey))
-	Expect(err).Should(BeNil())
-
-	gasFeeCap, gasTipCap, nonce := CalculateTxParams(ctx, subnetInfo, PrivateKeyToAddress(senderKey))
-
-	destinationTx := predicateutils.NewPredicateTx(
+	tx, err := transactionUtils.CreateReceiveCrossChainMessageTransaction(
+		ctx,
+		txLogger,
+		subnetInfo.RPCClient,
 		subnetInfo.EVMChainID,
-		nonce,
-		&teleporterContractAddress,
-		gasLimit,
-		gasFeeCap,
-		gasTipCap,
-		big.NewInt(0),
-		callData,
-		types.AccessList{},
-		warp.ContractAddress,
-		signedMessage.Bytes(),
+		signedMessage,
+		requiredGasLimit,
+		teleporterContractAddress,
+		senderKey,
 	)
-
-	return SignTransaction(destinationTx, senderKey, subnetInfo.EVMChainID)
+	Expect(err).Should(BeNil())
+	return tx
 }
 
 // Constructs a transaction to call addProtocolVersion
@@ -336,29 +303,17 @@ func CreateAddProtocolVersionTransaction(
 	senderKey *ecdsa.PrivateKey,
 	subnetInfo interfaces.SubnetTestInfo,
 ) *types.Transaction {
-	// Construct the transaction to send the Warp message to the destination chain
-	log.Info("Constructing addProtocolVersion transaction for the destination chain")
-
-	callData, err := teleporterregistry.PackAddProtocolVersion(0)
-	Expect(err).Should(BeNil())
-
-	gasFeeCap, gasTipCap, nonce := CalculateTxParams(ctx, subnetInfo, PrivateKeyToAddress(senderKey))
-
-	destinationTx := predicateutils.NewPredicateTx(
+	tx, err := transactionUtils.CreateAddProtocolVersionTransaction(
+		ctx,
+		txLogger,
+		subnetInfo.RPCClient,
 		subnetInfo.EVMChainID,
-		nonce,
-		&teleporterRegistryAddress,
-		500_000,
-		gasFeeCap,
-		gasTipCap,
-		big.NewInt(0),
-		callData,
-		types.AccessList{},
-		warp.ContractAddress,
-		signedMessage.Bytes(),
+		signedMessage,
+		teleporterRegistryAddress,
+		senderKey,
 	)
-
-	return SignTransaction(destinationTx, senderKey, subnetInfo.EVMChainID)
+	Expect(err).Should(BeNil())
+	return tx
 }
 
 func AddProtocolVersionAndWaitForAcceptance(
@@ -405,20 +360,16 @@ func CreateNativeTransferTransaction(
 	recipient common.Address,
 	amount *big.Int,
 ) *types.Transaction {
-	fromAddress := crypto.PubkeyToAddress(fromKey.PublicKey)
-	gasFeeCap, gasTipCap, nonce := CalculateTxParams(ctx, subnetInfo, fromAddress)
-
-	tx := types.NewTx(&types.DynamicFeeTx{
-		ChainID:   subnetInfo.EVMChainID,
-		Nonce:     nonce,
-		To:        &recipient,
-		Gas:       NativeTransferGas,
-		GasFeeCap: gasFeeCap,
-		GasTipCap: gasTipCap,
-		Value:     amount,
-	})
-
-	return SignTransaction(tx, fromKey, subnetInfo.EVMChainID)
+	tx, err := transactionUtils.CreateNativeTransferTransaction(
+		ctx,
+		subnetInfo.RPCClient,
+		subnetInfo.EVMChainID,
+		fromKey,
+		recipient,
+		amount,
+	)
+	Expect(err).Should(BeNil())
+	return tx
 }
 
 func SendNativeTransfer(
@@ -440,10 +391,13 @@ func sendAndWaitForTransaction(
 	tx *types.Transaction,
 	success bool,
 ) *types.Receipt {
-	err := subnetInfo.RPCClient.SendTransaction(ctx, tx)
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	receipt, err := transactionUtils.SendTransactionAndWait(cctx, txLogger, subnetInfo.RPCClient, tx)
 	Expect(err).Should(BeNil())
 
-	return waitForTransaction(ctx, subnetInfo, tx.Hash(), success)
+	return checkReceiptStatus(ctx, subnetInfo, receipt, success)
 }
 
 // Sends a tx, and waits for it to be mined.
@@ -529,6 +483,16 @@ func waitForTransaction(
 	receipt, err := WaitMined(cctx, subnetInfo.RPCClient, txHash)
 	Expect(err).Should(BeNil())
 
+	return checkReceiptStatus(ctx, subnetInfo, receipt, success)
+}
+
+// Asserts Receipt.status equals success, and prints a trace of the transaction if it unexpectedly failed.
+func checkReceiptStatus(
+	ctx context.Context,
+	subnetInfo interfaces.SubnetTestInfo,
+	receipt *types.Receipt,
+	success bool,
+) *types.Receipt {
 	if success {
 		if receipt.Status == types.ReceiptStatusFailed {
 			TraceTransactionAndExit(ctx, subnetInfo.RPCClient, receipt.TxHash)
@@ -539,95 +503,10 @@ func waitForTransaction(
 	return receipt
 }
 
-// Polls for a transaction receipt of the given txHash on each queryTicker tick until
-// either a transaction receipt returned, or the context is cancelled or expired.
-func waitForTransactionReceipt(
-	cctx context.Context,
-	rpcClient ethclient.Client,
-	txHash common.Hash,
-) (*types.Receipt, error) {
-	queryTicker := time.NewTicker(200 * time.Millisecond)
-	defer queryTicker.Stop()
-	for {
-		receipt, err := rpcClient.TransactionReceipt(cctx, txHash)
-		if err == nil {
-			return receipt, nil
-		}
-
-		if errors.Is(err, subnetEvmInterfaces.NotFound) {
-			log.Debug("Transaction not yet mined")
-		} else {
-			log.Error("Receipt retrieval failed", "err", err)
-			return nil, err
-		}
-
-		// Wait for the next round.
-		select {
-		case <-cctx.Done():
-			return nil, cctx.Err()
-		case <-queryTicker.C:
-		}
-	}
-}
-
-// Polls for the eth_blockNumber endpoint for the latest blockheight on each queryTicker tick until
-// either the returned height is greater than or equal to the expectedBlockNumber, or the context
-// is cancelled or expired.
-func waitForBlockHeight(
-	cctx context.Context,
-	rpcClient ethclient.Client,
-	expectedBlockNumber uint64,
-) error {
-	queryTicker := time.NewTicker(2 * time.Second)
-	defer queryTicker.Stop()
-	for {
-		currentBlockNumber, err := rpcClient.BlockNumber(cctx)
-		if err != nil {
-			return err
-		}
-
-		if currentBlockNumber >= expectedBlockNumber {
-			return nil
-		} else {
-			log.Info("Waiting for block height where transaction was included",
-				"blockNumber", expectedBlockNumber)
-		}
-
-		// Wait for the next round.
-		select {
-		case <-cctx.Done():
-			return cctx.Err()
-		case <-queryTicker.C:
-		}
-	}
-}
-
 // WaitMined waits for tx to be mined on the blockchain.
 // It stops waiting when the context is canceled.
-// Takes a tx hash instead of the full tx in the subnet-evm version of this function.
-// Copied and modified from https://github.com/ava-labs/subnet-evm/blob/v0.6.0-fuji/accounts/abi/bind/util.go#L42
 func WaitMined(ctx context.Context, rpcClient ethclient.Client, txHash common.Hash) (*types.Receipt, error) {
-	cctx, cancel := context.WithTimeout(ctx, 20*time.Second)
-	defer cancel()
-
-	receipt, err := waitForTransactionReceipt(cctx, rpcClient, txHash)
-	if err != nil {
-		return nil, err
-	}
-
-	// Check that the block height endpoint returns a block height as high as the block number that the transaction was
-	// included in. This is to workaround the issue where multiple nodes behind a public RPC endpoint see
-	// transactions/blocks at different points in time. Ideally, all nodes in the network should have seen this block
-	// and transaction before returning from WaitMined. The block height endpoint of public RPC endpoints is
-	// configured to return the lowest value currently returned by any node behind the load balancer, so waiting for
-	// it to be at least as high as the block height specified in the receipt should provide a relatively strong
-	// indication that the transaction has been seen widely throughout the network.
-	err = waitForBlockHeight(cctx, rpcClient, receipt.BlockNumber.Uint64())
-	if err != nil {
-		return nil, err
-	}
-
-	return receipt, nil
+	return transactionUtils.WaitMined(ctx, txLogger, rpcClient, txHash)
 }
 
 // Returns the first log in 'logs' that is successfully parsed by 'parser'
@@ -647,13 +526,7 @@ func GetEventFromLogsOrTrace[T any](
 
 // Returns the first log in 'logs' that is successfully parsed by 'parser'
 func GetEventFromLogs[T any](logs []*types.Log, parser func(log types.Log) (T, error)) (T, error) {
-	for _, log := range logs {
-		event, err := parser(*log)
-		if err == nil {
-			return event, nil
-		}
-	}
-	return *new(T), fmt.Errorf("failed to find %T event in receipt logs", *new(T))
+	return transactionUtils.GetEventFromLogs(logs, parser)
 }
 
 // Returns true if the transaction receipt contains a ReceiptReceived log with the specified messageID
@@ -717,8 +590,7 @@ func GetOutstandingReceiptCount(source interfaces.SubnetTestInfo, destinationBlo
 
 // Signs a transaction using the provided key for the specified chainID
 func SignTransaction(tx *types.Transaction, key *ecdsa.PrivateKey, chainID *big.Int) *types.Transaction {
-	txSigner := types.LatestSignerForChainID(chainID)
-	signedTx, err := types.SignTx(tx, txSigner, key)
+	signedTx, err := transactionUtils.SignTransaction(tx, key, chainID)
 	Expect(err).Should(BeNil())
 
 	return signedTx
@@ -730,23 +602,14 @@ func CalculateTxParams(
 	subnetInfo interfaces.SubnetTestInfo,
 	fundedAddress common.Address,
 ) (*big.Int, *big.Int, uint64) {
-	baseFee, err := subnetInfo.RPCClient.EstimateBaseFee(ctx)
+	gasFeeCap, gasTipCap, nonce, err := transactionUtils.CalculateTxParams(ctx, subnetInfo.RPCClient, fundedAddress)
 	Expect(err).Should(BeNil())
 
-	gasTipCap, err := subnetInfo.RPCClient.SuggestGasTipCap(ctx)
-	Expect(err).Should(BeNil())
-
-	nonce, err := subnetInfo.RPCClient.NonceAt(ctx, fundedAddress, nil)
-	Expect(err).Should(BeNil())
-
-	gasFeeCap := baseFee.Mul(baseFee, big.NewInt(gasUtils.BaseFeeFactor))
-	gasFeeCap.Add(gasFeeCap, big.NewInt(gasUtils.MaxPriorityFeePerGas))
-
 	return gasFeeCap, gasTipCap, nonce
 }
 
 func PrivateKeyToAddress(k *ecdsa.PrivateKey) common.Address {
-	return crypto.PubkeyToAddress(k.PublicKey)
+	return transactionUtils.PrivateKeyToAddress(k)
 }
 
 // Throws a Gomega error if there is a mismatch
@@ -762,15 +625,10 @@ func TraceTransactionAndExit(ctx context.Context, rpcClient ethclient.Client, tx
 }
 
 func TraceTransaction(ctx context.Context, rpcClient ethclient.Client, txHash common.Hash) string {
-	var result interface{}
-	ct := "callTracer"
-	err := rpcClient.Client().Call(&result, "debug_traceTransaction", txHash.String(), tracers.TraceConfig{Tracer: &ct})
-	Expect(err).Should(BeNil())
-
-	jsonStr, err := json.Marshal(result)
+	trace, err := transactionUtils.TraceTransaction(ctx, rpcClient, txHash)
 	Expect(err).Should(BeNil())
 
-	return string(jsonStr)
+	return trace
 }
 
 func DeployContract(
@@ -781,27 +639,20 @@ func DeployContract(
 	abi *abi.ABI,
 	constructorArgs ...interface{},
 ) {
-	// Deploy an example ERC20 contract to be used as the source token
-	byteCode, err := deploymentUtils.ExtractByteCode(byteCodeFileName)
-	Expect(err).Should(BeNil())
-	Expect(len(byteCode) > 0).Should(BeTrue())
-	transactor, err := bind.NewKeyedTransactorWithChainID(deployerPK, subnetInfo.EVMChainID)
-	Expect(err).Should(BeNil())
-	contractAddress, tx, _, err := bind.DeployContract(
-		transactor,
-		*abi,
-		byteCode,
+	_, receipt, err := transactionUtils.DeployContract(
+		ctx,
+		txLogger,
 		subnetInfo.RPCClient,
+		subnetInfo.EVMChainID,
+		byteCodeFileName,
+		deployerPK,
+		abi,
 		constructorArgs...,
 	)
+	if receipt != nil && receipt.Status == types.ReceiptStatusFailed {
+		TraceTransactionAndExit(ctx, subnetInfo.RPCClient, receipt.TxHash)
+	}
 	Expect(err).Should(BeNil())
-
-	// Wait for transaction, then check code was deployed
-	WaitForTransactionSuccess(ctx, subnetInfo, tx.Hash())
-
-	code, err := subnetInfo.RPCClient.CodeAt(ctx, contractAddress, nil)
-	Expect(err).Should(BeNil())
-	Expect(len(code)).Should(BeNumerically(">", 2)) // 0x is an EOA, contract returns the bytecode
 }
 
 func ExpectBigEqual(v1 *big.Int, v2 *big.Int) {
@@ -4,53 +4,304 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
-	"log"
+	"io"
+	"math/big"
 	"os"
 	"strconv"
 
 	deploymentUtils "github.com/ava-labs/teleporter/utils/deployment-utils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
 )
 
-func main() {
-	if len(os.Args) < 2 {
-		log.Panic("Invalid argument count. Must provide at least one argument to specify command type.")
-	}
-	commandType := os.Args[1]
+var (
+	jsonOutput      bool
+	gasPrice        string
+	gasLimit        uint64
+	outputDir       string
+	constructorArgs string
+	filePrefix      string
+)
+
+// keylessTxOutput is the JSON output of the commands constructing a keyless transaction
+type keylessTxOutput struct {
+	Transaction     string         `json:"transaction"`
+	DeployerAddress common.Address `json:"deployerAddress"`
+	ContractAddress common.Address `json:"contractAddress"`
+	// Amount in wei, encoded as a decimal string to avoid losing precision
+	RequiredFunding string `json:"requiredFunding"`
+}
+
+// create2TxOutput is the JSON output of the constructCreate2Tx command
+type create2TxOutput struct {
+	FactoryAddress  common.Address `json:"factoryAddress"`
+	Salt            common.Hash    `json:"salt"`
+	CallData        string         `json:"callData"`
+	ContractAddress common.Address `json:"contractAddress"`
+}
+
+// addressOutput is the JSON output of the commands deriving a contract address
+type addressOutput struct {
+	ContractAddress common.Address `json:"contractAddress"`
+}
+
+var rootCmd = &cobra.Command{
+	Use:   "contractDeploymentTools",
+	Short: "Tools to deploy contracts to the same address on every EVM chain",
+	Long: `Constructs keyless contract creation transactions using Nick's method, and calls to a
+CREATE2 factory, that deploy a contract to the same address on every EVM chain.`,
+	SilenceUsage: true,
+}
 
-	switch commandType {
-	case "constructKeylessTx":
-		// Get the byte code of the teleporter contract to be deployed.
-		if len(os.Args) != 3 {
-			log.Panic("Invalid argument count. Must provide JSON file containing contract bytecode.")
+var constructKeylessTxCmd = &cobra.Command{
+	Use:   "constructKeylessTx PATH_TO_CONTRACT_JSON_FILE",
+	Short: "Constructs a keyless transaction deploying the contract in a forge build artifact",
+	Long: `Constructs a keyless transaction using Nick's method that deploys the contract in a
+forge build artifact, and writes the raw transaction, the keyless deployer address that
+must be funded to send it, and the resulting contract address to the output directory.
+The files are named for TeleporterMessenger unless --file-prefix is set.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		byteCode, err := deploymentUtils.ExtractByteCode(args[0])
+		if err != nil {
+			return err
 		}
-		_, _, _, err := deploymentUtils.ConstructKeylessTransaction(
-			os.Args[2],
-			true,
-			deploymentUtils.GetDefaultContractCreationGasPrice(),
-		)
+		params := deploymentUtils.KeylessTransactionParams{
+			ByteCode: byteCode,
+			GasLimit: gasLimit,
+		}
+		if params.ConstructorArgs, err = parseConstructorArgs(); err != nil {
+			return err
+		}
+		if gasPrice != "" {
+			price, ok := new(big.Int).SetString(gasPrice, 10)
+			if !ok || price.Sign() <= 0 {
+				return fmt.Errorf("invalid gas price %s, must be a positive amount in wei", gasPrice)
+			}
+			params.GasPrice = price
+		}
+		deployment, err := deploymentUtils.ConstructKeylessDeployment(params)
 		if err != nil {
-			log.Panic("Failed to construct keyless transaction.", err)
+			return err
 		}
-	case "deriveContractAddress":
-		// Get the byte code of the teleporter contract to be deployed.
-		if len(os.Args) != 4 {
-			log.Panic("Invalid argument count. Must provide address and nonce.")
+		files := deploymentUtils.TeleporterKeylessDeploymentFiles
+		if filePrefix != "" {
+			files = deploymentUtils.NewKeylessDeploymentFiles(filePrefix)
 		}
+		return writeKeylessDeployment(cmd.OutOrStdout(), deployment, files)
+	},
+}
 
-		deployerAddress := common.HexToAddress(os.Args[2])
-		nonce, err := strconv.ParseUint(os.Args[3], 10, 64)
+var deriveContractAddressCmd = &cobra.Command{
+	Use:   "deriveContractAddress DEPLOYER_ADDRESS NONCE",
+	Short: "Derives the address of a contract created by an account with the given nonce",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		deployerAddress, err := parseAddress(args[0])
 		if err != nil {
-			log.Panic("Failed to parse nonce as uint", err)
+			return err
+		}
+		nonce, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse nonce as uint: %w", err)
 		}
-
 		resultAddress, err := deploymentUtils.DeriveEVMContractAddress(deployerAddress, nonce)
 		if err != nil {
-			log.Panic("Failed to derive contract address.", err)
+			return err
 		}
-		fmt.Println(resultAddress.Hex())
-	default:
-		log.Panic("Invalid command type. Supported options are \"constructKeylessTx\" and \"deriveContractAddress\".")
+		return writeAddress(cmd.OutOrStdout(), resultAddress)
+	},
+}
+
+var constructCreate2FactoryTxCmd = &cobra.Command{
+	Use:   "constructCreate2FactoryTx",
+	Short: "Constructs the keyless transaction deploying the CREATE2 factory",
+	Long: `Constructs the keyless transaction using Nick's method that deploys the CREATE2 factory,
+and writes the raw transaction, the keyless deployer address that must be funded to send it,
+and the factory address to the output directory. The transaction is the presigned transaction
+of the deterministic deployment proxy, so the factory is deployed to its standard address,
+where it already exists on many chains.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		deployment, err := deploymentUtils.ConstructCreate2FactoryDeployment()
+		if err != nil {
+			return err
+		}
+		return writeKeylessDeployment(cmd.OutOrStdout(), deployment, deploymentUtils.Create2FactoryKeylessDeploymentFiles)
+	},
+}
+
+var constructCreate2TxCmd = &cobra.Command{
+	Use:   "constructCreate2Tx PATH_TO_CONTRACT_JSON_FILE SALT",
+	Short: "Constructs the call to the CREATE2 factory deploying the contract in a forge build artifact",
+	Long: `Constructs the calldata to send to the CREATE2 factory to deploy the contract in a forge
+build artifact with the given salt and constructor arguments, and derives the address the
+contract is deployed to. The calldata can be sent to the factory from any funded account.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		byteCode, err := deploymentUtils.ExtractByteCode(args[0])
+		if err != nil {
+			return err
+		}
+		salt, err := parseHash(args[1])
+		if err != nil {
+			return err
+		}
+		encodedArgs, err := parseConstructorArgs()
+		if err != nil {
+			return err
+		}
+		factoryAddress, err := deploymentUtils.Create2FactoryAddress()
+		if err != nil {
+			return err
+		}
+		deployment, err := deploymentUtils.ConstructCreate2Deployment(factoryAddress, salt, byteCode, encodedArgs)
+		if err != nil {
+			return err
+		}
+
+		out := create2TxOutput{
+			FactoryAddress:  deployment.FactoryAddress,
+			Salt:            deployment.Salt,
+			CallData:        hexutil.Encode(deployment.CallData),
+			ContractAddress: deployment.ContractAddress,
+		}
+		if jsonOutput {
+			return writeJSON(cmd.OutOrStdout(), out)
+		}
+		_, err = fmt.Fprintf(
+			cmd.OutOrStdout(),
+			"CREATE2 Factory Address: %s\nFactory Calldata: %s\nContract Address: %s\n",
+			out.FactoryAddress.Hex(),
+			out.CallData,
+			out.ContractAddress.Hex(),
+		)
+		return err
+	},
+}
+
+var deriveCreate2AddressCmd = &cobra.Command{
+	Use:   "deriveCreate2Address DEPLOYER_ADDRESS SALT INIT_CODE_HASH",
+	Short: "Derives the address of a contract created with CREATE2",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		deployerAddress, err := parseAddress(args[0])
+		if err != nil {
+			return err
+		}
+		salt, err := parseHash(args[1])
+		if err != nil {
+			return err
+		}
+		initCodeHash, err := parseHash(args[2])
+		if err != nil {
+			return err
+		}
+		return writeAddress(
+			cmd.OutOrStdout(),
+			deploymentUtils.DeriveCreate2ContractAddress(deployerAddress, salt, initCodeHash),
+		)
+	},
+}
+
+// writeKeylessDeployment writes the keyless deployment to the output, and to the files in the output directory
+func writeKeylessDeployment(
+	w io.Writer,
+	deployment *deploymentUtils.KeylessDeployment,
+	files deploymentUtils.KeylessDeploymentFiles,
+) error {
+	if outputDir != "" {
+		if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		if err := deployment.WriteFiles(outputDir, files); err != nil {
+			return err
+		}
+	}
+	if jsonOutput {
+		return writeJSON(w, keylessTxOutput{
+			Transaction:     hexutil.Encode(deployment.TransactionBytes),
+			DeployerAddress: deployment.DeployerAddress,
+			ContractAddress: deployment.ContractAddress,
+			RequiredFunding: deployment.RequiredFunding.String(),
+		})
+	}
+	return deployment.Write(w)
+}
+
+func writeAddress(w io.Writer, address common.Address) error {
+	if jsonOutput {
+		return writeJSON(w, addressOutput{ContractAddress: address})
+	}
+	_, err := fmt.Fprintln(w, address.Hex())
+	return err
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func parseAddress(s string) (common.Address, error) {
+	if !common.IsHexAddress(s) {
+		return common.Address{}, fmt.Errorf("invalid address %s", s)
+	}
+	return common.HexToAddress(s), nil
+}
+
+// parseHash parses a hex encoded value of up to 32 bytes, left padded with zeros
+func parseHash(s string) (common.Hash, error) {
+	b, err := hexutil.Decode(s)
+	if err != nil || len(b) > common.HashLength {
+		return common.Hash{}, fmt.Errorf("invalid 32 byte hex value %s", s)
+	}
+	return common.BytesToHash(b), nil
+}
+
+func parseConstructorArgs() ([]byte, error) {
+	if constructorArgs == "" {
+		return nil, nil
+	}
+	b, err := hexutil.Decode(constructorArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode constructor arguments as hex: %w", err)
+	}
+	return b, nil
+}
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Print the output as JSON")
+
+	constructKeylessTxCmd.Flags().StringVar(&gasPrice, "gas-price", "",
+		"Gas price of the transaction in wei, 2500 nAVAX if not provided")
+	constructKeylessTxCmd.Flags().Uint64Var(&gasLimit, "gas-limit", 0,
+		"Gas limit of the transaction, 4,000,000 if not provided")
+	constructKeylessTxCmd.Flags().StringVar(&filePrefix, "file-prefix", "",
+		"Prefix of the output file names, such as UniversalMyContract, to deploy a contract other than TeleporterMessenger")
+	for _, cmd := range []*cobra.Command{constructKeylessTxCmd, constructCreate2TxCmd} {
+		cmd.Flags().StringVar(&constructorArgs, "constructor-args", "",
+			"Hex encoded ABI encoded constructor arguments appended to the contract bytecode")
+	}
+	for _, cmd := range []*cobra.Command{constructKeylessTxCmd, constructCreate2FactoryTxCmd} {
+		cmd.Flags().StringVar(&outputDir, "output-dir", ".",
+			"Directory the transaction and addresses are written to, or empty to not write files")
+	}
+
+	rootCmd.AddCommand(
+		constructKeylessTxCmd,
+		deriveContractAddressCmd,
+		constructCreate2FactoryTxCmd,
+		constructCreate2TxCmd,
+		deriveCreate2AddressCmd,
+	)
+}
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
 	}
 }
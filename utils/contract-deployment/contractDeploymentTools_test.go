@@ -0,0 +1,183 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func executeTestCmd(t *testing.T, args ...string) (string, error) {
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs(args)
+	defer func() {
+		jsonOutput = false
+		gasPrice = ""
+		gasLimit = 0
+		outputDir = "."
+		constructorArgs = ""
+		filePrefix = ""
+	}()
+
+	err := rootCmd.Execute()
+	return strings.TrimSpace(buf.String()), err
+}
+
+func writeTestArtifact(t *testing.T) string {
+	fileName := filepath.Join(t.TempDir(), "Contract.json")
+	artifact := `{"bytecode": {"object": "0x6020600c60003960206000f3"}}`
+	require.NoError(t, os.WriteFile(fileName, []byte(artifact), 0o600))
+	return fileName
+}
+
+func TestConstructKeylessTxCmd(t *testing.T) {
+	artifact := writeTestArtifact(t)
+	dir := filepath.Join(t.TempDir(), "out")
+
+	out, err := executeTestCmd(t, "constructKeylessTx", artifact,
+		"--gas-price", "25000000000", "--gas-limit", "100000", "--output-dir", dir, "--json")
+	require.NoError(t, err)
+	var result keylessTxOutput
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	require.Equal(t, "2500000000000000", result.RequiredFunding)
+
+	b, err := os.ReadFile(filepath.Join(dir, "UniversalTeleporterDeployerTransaction.txt"))
+	require.NoError(t, err)
+	require.Equal(t, result.Transaction, string(b))
+	b, err = os.ReadFile(filepath.Join(dir, "UniversalTeleporterMessengerContractAddress.txt"))
+	require.NoError(t, err)
+	require.Equal(t, result.ContractAddress, common.HexToAddress(string(b)))
+
+	// Other contracts are written to files named with the prefix.
+	dir = filepath.Join(t.TempDir(), "out")
+	out, err = executeTestCmd(t, "constructKeylessTx", artifact,
+		"--output-dir", dir, "--file-prefix", "UniversalMyContract", "--json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	require.ElementsMatch(t, []string{
+		"UniversalMyContractDeployerTransaction.txt",
+		"UniversalMyContractDeployerAddress.txt",
+		"UniversalMyContractContractAddress.txt",
+	}, names)
+	b, err = os.ReadFile(filepath.Join(dir, "UniversalMyContractContractAddress.txt"))
+	require.NoError(t, err)
+	require.Equal(t, result.ContractAddress, common.HexToAddress(string(b)))
+
+	var tests = []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{
+			name: "no args",
+			args: []string{"constructKeylessTx"},
+			err:  "accepts 1 arg(s), received 0",
+		},
+		{
+			name: "invalid gas price",
+			args: []string{"constructKeylessTx", artifact, "--gas-price", "-1"},
+			err:  "invalid gas price -1, must be a positive amount in wei",
+		},
+		{
+			name: "invalid constructor args",
+			args: []string{"constructKeylessTx", artifact, "--constructor-args", "zz"},
+			err:  "failed to decode constructor arguments as hex",
+		},
+		{
+			name: "missing artifact",
+			args: []string{"constructKeylessTx", filepath.Join(t.TempDir(), "missing.json")},
+			err:  "Failed to read bytecode file contents",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := executeTestCmd(t, tt.args...)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestConstructCreate2TxCmd(t *testing.T) {
+	artifact := writeTestArtifact(t)
+
+	out, err := executeTestCmd(t, "constructCreate2Tx", artifact, "0x01", "--constructor-args", "0x2a", "--json")
+	require.NoError(t, err)
+	var result create2TxOutput
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	require.Equal(t, common.HexToHash("0x01"), result.Salt)
+	require.True(t, strings.HasSuffix(result.CallData, "6020600c60003960206000f32a"))
+
+	// The factory address matches the address of the factory deployment.
+	out, err = executeTestCmd(t, "constructCreate2FactoryTx", "--output-dir", "", "--json")
+	require.NoError(t, err)
+	var factory keylessTxOutput
+	require.NoError(t, json.Unmarshal([]byte(out), &factory))
+	require.Equal(t, factory.ContractAddress, result.FactoryAddress)
+}
+
+func TestDeriveAddressCmds(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		out  string
+		err  string
+	}{
+		{
+			name: "deriveContractAddress",
+			args: []string{"deriveContractAddress", "0x38545c4b331D8BFb3bee94C62D77a6735b5eF8c0", "1"},
+			out:  "0xF685C65db284F402208936353FD7779e00988F30",
+		},
+		{
+			name: "deriveContractAddress invalid nonce",
+			args: []string{"deriveContractAddress", "0x38545c4b331D8BFb3bee94C62D77a6735b5eF8c0", "a"},
+			err:  "failed to parse nonce as uint",
+		},
+		{
+			name: "deriveCreate2Address",
+			args: []string{
+				"deriveCreate2Address",
+				"0x00000000000000000000000000000000deadbeef",
+				"0xcafebabe",
+				"0xd4fd4e189132273036449fc9e11198c739161b4c0116a9a2dccdfa1c492006f1",
+			},
+			out: "0x60f3f640a8508fC6a86d45DF051962668E1e8AC7",
+		},
+		{
+			name: "deriveCreate2Address invalid address",
+			args: []string{"deriveCreate2Address", "0x00", "0x00", "0x00"},
+			err:  "invalid address 0x00",
+		},
+		{
+			name: "deriveCreate2Address invalid salt",
+			args: []string{"deriveCreate2Address", "0x00000000000000000000000000000000deadbeef", "salt", "0x00"},
+			err:  "invalid 32 byte hex value salt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, tt.args...)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.out, out)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Creation bytecode of the deterministic deployment proxy
+	// (https://github.com/Arachnid/deterministic-deployment-proxy). The deployed contract takes a 32 byte
+	// salt followed by the init code of a contract as calldata, creates the contract with CREATE2, and
+	// returns its address.
+	create2FactoryByteCodeHex = "604580600e600039806000f350fe" +
+		"7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe0" +
+		"3601600081602082378035828234f58015156039578182fd5b8082525050506014600cf3"
+
+	// Gas limit, gas price, and R and S values of the presigned transaction that deploys the deterministic
+	// deployment proxy. They must not be changed, so that the factory is deployed to its standard address,
+	// 0x4e59b44847b379578588920ca78fbf26c0b4956c, where it already exists on many chains.
+	create2FactoryGasLimit   = uint64(100000)
+	create2FactoryRSValueHex = "2222222222222222222222222222222222222222222222222222222222222222"
+
+	create2FactoryTxFileName           = "UniversalCreate2FactoryDeployerTransaction.txt"
+	create2FactoryDeployerAddrFileName = "UniversalCreate2FactoryDeployerAddress.txt"
+	create2FactoryContractAddrFileName = "UniversalCreate2FactoryContractAddress.txt"
+)
+
+var create2FactoryGasPrice = big.NewInt(100e9) // 100 nAVAX/gas
+
+// Create2FactoryKeylessDeploymentFiles are the file names used for the CREATE2 factory deployment
+var Create2FactoryKeylessDeploymentFiles = KeylessDeploymentFiles{
+	Transaction:     create2FactoryTxFileName,
+	DeployerAddress: create2FactoryDeployerAddrFileName,
+	ContractAddress: create2FactoryContractAddrFileName,
+}
+
+// Create2Deployment is a call to the CREATE2 factory that deploys a contract to an address determined
+// only by the factory address, the salt, and the init code of the contract
+type Create2Deployment struct {
+	FactoryAddress common.Address
+	Salt           common.Hash
+	// Calldata of the transaction sent to the factory
+	CallData []byte
+	// Address of the contract created by the factory
+	ContractAddress common.Address
+}
+
+// Constructs the presigned keyless transaction using Nick's method that deploys the deterministic
+// deployment proxy used as the CREATE2 factory. The factory is deployed to its standard address on every
+// chain that the transaction is sent on, so the transaction only needs to be sent on chains where the
+// factory does not exist yet.
+func ConstructCreate2FactoryDeployment() (*KeylessDeployment, error) {
+	byteCode, err := decodeByteCode(create2FactoryByteCodeHex)
+	if err != nil {
+		return nil, err
+	}
+	rsValue, ok := new(big.Int).SetString(create2FactoryRSValueHex, 16)
+	if !ok {
+		return nil, errors.New("Failed to convert R and S value to big.Int.")
+	}
+	return ConstructKeylessDeployment(KeylessTransactionParams{
+		ByteCode:       byteCode,
+		GasLimit:       create2FactoryGasLimit,
+		GasPrice:       create2FactoryGasPrice,
+		SignatureValue: rsValue,
+	})
+}
+
+// Create2FactoryAddress returns the address of the CREATE2 factory deployed by ConstructCreate2FactoryDeployment
+func Create2FactoryAddress() (common.Address, error) {
+	deployment, err := ConstructCreate2FactoryDeployment()
+	if err != nil {
+		return common.Address{}, err
+	}
+	return deployment.ContractAddress, nil
+}
+
+// DeriveCreate2ContractAddress returns the address of a contract created with CREATE2 by the deployer
+// using the given salt and the keccak256 hash of the contract's init code, as specified in EIP-1014
+func DeriveCreate2ContractAddress(deployer common.Address, salt common.Hash, initCodeHash common.Hash) common.Address {
+	return crypto.CreateAddress2(deployer, salt, initCodeHash.Bytes())
+}
+
+// Constructs the call to the CREATE2 factory that deploys the contract with the given constructor arguments
+func ConstructCreate2Deployment(
+	factoryAddress common.Address,
+	salt common.Hash,
+	byteCode []byte,
+	constructorArgs []byte,
+) (*Create2Deployment, error) {
+	if len(byteCode) == 0 {
+		return nil, errors.New("Contract bytecode must not be empty.")
+	}
+	initCode := make([]byte, 0, len(byteCode)+len(constructorArgs))
+	initCode = append(initCode, byteCode...)
+	initCode = append(initCode, constructorArgs...)
+
+	callData := make([]byte, 0, common.HashLength+len(initCode))
+	callData = append(callData, salt.Bytes()...)
+	callData = append(callData, initCode...)
+
+	return &Create2Deployment{
+		FactoryAddress:  factoryAddress,
+		Salt:            salt,
+		CallData:        callData,
+		ContractAddress: DeriveCreate2ContractAddress(factoryAddress, salt, crypto.Keccak256Hash(initCode)),
+	}, nil
+}
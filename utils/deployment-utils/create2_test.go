@@ -0,0 +1,109 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriveCreate2ContractAddress(t *testing.T) {
+	// Examples from EIP-1014
+	var tests = []struct {
+		deployer string
+		salt     string
+		initCode []byte
+		expected string
+	}{
+		{
+			deployer: "0x0000000000000000000000000000000000000000",
+			salt:     "0x0000000000000000000000000000000000000000000000000000000000000000",
+			initCode: []byte{0x00},
+			expected: "0x4D1A2e2bB4F88F0250f26Ffff098B0b30B26BF38",
+		},
+		{
+			deployer: "0xdeadbeef00000000000000000000000000000000",
+			salt:     "0x000000000000000000000000feed000000000000000000000000000000000000",
+			initCode: []byte{0x00},
+			expected: "0xD04116cDd17beBE565EB2422F2497E06cC1C9833",
+		},
+		{
+			deployer: "0x00000000000000000000000000000000deadbeef",
+			salt:     "0x00000000000000000000000000000000000000000000000000000000cafebabe",
+			initCode: common.FromHex("0xdeadbeef"),
+			expected: "0x60f3f640a8508fC6a86d45DF051962668E1e8AC7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			address := DeriveCreate2ContractAddress(
+				common.HexToAddress(tt.deployer),
+				common.HexToHash(tt.salt),
+				crypto.Keccak256Hash(tt.initCode),
+			)
+			require.Equal(t, common.HexToAddress(tt.expected), address)
+		})
+	}
+}
+
+// Presigned transaction published at https://github.com/Arachnid/deterministic-deployment-proxy
+const create2FactoryTxHex = "0xf8a58085174876e800830186a08080b853604580600e600039806000f350fe" +
+	"7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe0" +
+	"3601600081602082378035828234f58015156039578182fd5b8082525050506014600cf3" +
+	"1ba02222222222222222222222222222222222222222222222222222222222222222" +
+	"a02222222222222222222222222222222222222222222222222222222222222222"
+
+func TestConstructCreate2FactoryDeployment(t *testing.T) {
+	deployment, err := ConstructCreate2FactoryDeployment()
+	require.NoError(t, err)
+	require.Equal(t, create2FactoryGasLimit, deployment.Transaction.Gas())
+
+	// The transaction is the presigned transaction of the deterministic deployment proxy, which deploys
+	// the factory to its standard address.
+	require.Equal(t, create2FactoryTxHex, hexutil.Encode(deployment.TransactionBytes))
+	require.Equal(t, common.HexToAddress("0x3fab184622dc19b6109349b94811493bf2a45362"), deployment.DeployerAddress)
+	require.Equal(t, common.HexToAddress("0x4e59b44847b379578588920ca78fbf26c0b4956c"), deployment.ContractAddress)
+	require.Equal(t, big.NewInt(1e16), deployment.RequiredFunding)
+
+	// The factory is deployed to the same address every time.
+	factoryAddress, err := Create2FactoryAddress()
+	require.NoError(t, err)
+	require.Equal(t, deployment.ContractAddress, factoryAddress)
+}
+
+func TestConstructCreate2Deployment(t *testing.T) {
+	// Runtime code that returns the 32 byte constructor argument appended to it as the deployed code
+	byteCode := common.FromHex("0x6020600c60003960206000f3")
+	constructorArgs := common.LeftPadBytes([]byte{0x2a}, 32)
+	salt := common.HexToHash("0x01")
+
+	_, err := ConstructCreate2Deployment(common.Address{}, salt, nil, nil)
+	require.ErrorContains(t, err, "Contract bytecode must not be empty.")
+
+	// Deploy the factory, and deploy the contract through it.
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	cfg := &runtime.Config{State: statedb, GasLimit: create2FactoryGasLimit * 10}
+	factoryDeployment, err := ConstructCreate2FactoryDeployment()
+	require.NoError(t, err)
+	_, factoryAddress, _, err := runtime.Create(factoryDeployment.Transaction.Data(), cfg)
+	require.NoError(t, err)
+
+	deployment, err := ConstructCreate2Deployment(factoryAddress, salt, byteCode, constructorArgs)
+	require.NoError(t, err)
+	ret, _, err := runtime.Call(factoryAddress, deployment.CallData, cfg)
+	require.NoError(t, err)
+	require.Equal(t, deployment.ContractAddress, common.BytesToAddress(ret))
+	require.Equal(t, constructorArgs, statedb.GetCode(deployment.ContractAddress))
+}
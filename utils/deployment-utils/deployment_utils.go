@@ -4,15 +4,19 @@
 package utils
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/fs"
 	"log"
 	"math/big"
 	"os"
+	"path/filepath"
 
 	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -23,6 +27,9 @@ const (
 	// Roughly 3,010,000 gas needed to deploy contract. Padded to account for possible additions
 	defaultContractCreationGasLimit = uint64(4000000)
 
+	// Padding added to estimated contract creation gas, in percent
+	contractCreationGasPaddingPercent = 25
+
 	// R and S values to use in a keyless transaction signature.
 	// The values do not technically need to be the same when using Nick's method, but the AvalancheGo
 	// APIs by default only allow legacy transactions to be broadcast if they have the same R and S values,
@@ -41,11 +48,13 @@ var (
 )
 
 type byteCodeObj struct {
-	Object string `json:"object"`
+	Object              string                 `json:"object"`
+	ImmutableReferences map[string][]CodeRange `json:"immutableReferences"`
 }
 
 type byteCodeFile struct {
-	ByteCode byteCodeObj `json:"bytecode"`
+	ByteCode         byteCodeObj `json:"bytecode"`
+	DeployedByteCode byteCodeObj `json:"deployedBytecode"`
 }
 
 func DeriveEVMContractAddress(sender common.Address, nonce uint64) (common.Address, error) {
@@ -62,7 +71,16 @@ func DeriveEVMContractAddress(sender common.Address, nonce uint64) (common.Addre
 	return common.HexToAddress(fmt.Sprintf("0x%x", hash.Bytes()[12:])), nil
 }
 
+// ExtractByteCode returns the creation bytecode from a forge build artifact
 func ExtractByteCode(byteCodeFileName string) ([]byte, error) {
+	byteCodeJSON, err := readByteCodeFile(byteCodeFileName)
+	if err != nil {
+		return nil, err
+	}
+	return decodeByteCode(byteCodeJSON.ByteCode.Object)
+}
+
+func readByteCodeFile(byteCodeFileName string) (*byteCodeFile, error) {
 	log.Println("Using bytecode file at", byteCodeFileName)
 	byteCodeFileContents, err := os.ReadFile(byteCodeFileName)
 	if err != nil {
@@ -73,7 +91,10 @@ func ExtractByteCode(byteCodeFileName string) ([]byte, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to unmarshal bytecode file contents as JSON")
 	}
-	byteCodeString := byteCodeJSON.ByteCode.Object
+	return &byteCodeJSON, nil
+}
+
+func decodeByteCode(byteCodeString string) ([]byte, error) {
 	if len(byteCodeString) < 2 {
 		return nil, errors.New("Invalid byte code length.")
 	}
@@ -88,35 +109,102 @@ func ExtractByteCode(byteCodeFileName string) ([]byte, error) {
 	return byteCode, nil
 }
 
+// KeylessTransactionParams configures a keyless contract creation transaction
+type KeylessTransactionParams struct {
+	// Creation bytecode of the contract
+	ByteCode []byte
+	// ABI encoded constructor arguments, appended to the creation bytecode
+	ConstructorArgs []byte
+	// Gas limit of the transaction. Defaults to 4,000,000, which is enough to deploy TeleporterMessenger.
+	// Use EstimateContractCreationGas to estimate the gas limit for other contracts.
+	GasLimit uint64
+	// Gas price of the transaction. Defaults to GetDefaultContractCreationGasPrice.
+	GasPrice *big.Int
+	// R and S values of the transaction signature. Defaults to 0x3333...3333. Only needed to reproduce a
+	// keyless transaction presigned with other values, which is sent from a different deployer address.
+	SignatureValue *big.Int
+}
+
+// KeylessDeployment is a contract creation transaction using Nick's method, which can be sent on
+// any EVM chain to deploy the contract to the same address once the deployer address is funded
+type KeylessDeployment struct {
+	Transaction      *types.Transaction
+	TransactionBytes []byte
+	// Address the transaction is sent from. No private key is known for the address.
+	DeployerAddress common.Address
+	// Address of the contract created by the transaction
+	ContractAddress common.Address
+	// Amount in wei the deployer address must hold to pay for the transaction
+	RequiredFunding *big.Int
+}
+
+// KeylessDeploymentFiles are the names of the files a KeylessDeployment is written to
+type KeylessDeploymentFiles struct {
+	Transaction     string
+	DeployerAddress string
+	ContractAddress string
+}
+
+// TeleporterKeylessDeploymentFiles are the file names used for the TeleporterMessenger deployment
+var TeleporterKeylessDeploymentFiles = KeylessDeploymentFiles{
+	Transaction:     contractCreationTxFileName,
+	DeployerAddress: contractCreationAddrFileName,
+	ContractAddress: universalContractAddressFileName,
+}
+
+// NewKeylessDeploymentFiles returns the file names used for the deployment of a contract other than
+// TeleporterMessenger: <prefix>DeployerTransaction.txt, <prefix>DeployerAddress.txt, and
+// <prefix>ContractAddress.txt
+func NewKeylessDeploymentFiles(prefix string) KeylessDeploymentFiles {
+	return KeylessDeploymentFiles{
+		Transaction:     prefix + "DeployerTransaction.txt",
+		DeployerAddress: prefix + "DeployerAddress.txt",
+		ContractAddress: prefix + "ContractAddress.txt",
+	}
+}
+
+// GasEstimator estimates the gas used by a transaction, such as an ethclient.Client
+type GasEstimator interface {
+	EstimateGas(ctx context.Context, call interfaces.CallMsg) (uint64, error)
+}
+
 // Constructs a keyless transaction using Nick's method
-// Optionally writes the transaction, deployer address, and contract address to file
-// Returns the transaction bytes, deployer address, and contract address
-func ConstructKeylessTransaction(
-	byteCodeFileName string,
-	writeFile bool,
-	contractCreationGasPrice *big.Int,
-) ([]byte, common.Address, common.Address, error) {
-	// Convert the R and S values (which must be the same) from hex.
-	rsValue, ok := new(big.Int).SetString(rsValueHex, 16)
-	if !ok {
-		return nil, common.Address{}, common.Address{}, errors.New(
-			"Failed to convert R and S value to big.Int.",
-		)
+// Returns the transaction along with the deployer address, contract address, and required funding
+func ConstructKeylessDeployment(params KeylessTransactionParams) (*KeylessDeployment, error) {
+	if len(params.ByteCode) == 0 {
+		return nil, errors.New("Contract bytecode must not be empty.")
+	}
+	gasLimit := params.GasLimit
+	if gasLimit == 0 {
+		gasLimit = defaultContractCreationGasLimit
+	}
+	gasPrice := params.GasPrice
+	if gasPrice == nil {
+		gasPrice = GetDefaultContractCreationGasPrice()
 	}
 
-	byteCode, err := ExtractByteCode(byteCodeFileName)
-	if err != nil {
-		return nil, common.Address{}, common.Address{}, err
+	// Convert the R and S values (which must be the same) from hex.
+	rsValue := params.SignatureValue
+	if rsValue == nil {
+		var ok bool
+		rsValue, ok = new(big.Int).SetString(rsValueHex, 16)
+		if !ok {
+			return nil, errors.New("Failed to convert R and S value to big.Int.")
+		}
 	}
 
+	data := make([]byte, 0, len(params.ByteCode)+len(params.ConstructorArgs))
+	data = append(data, params.ByteCode...)
+	data = append(data, params.ConstructorArgs...)
+
 	// Construct the legacy transaction with pre-determined signature values.
 	contractCreationTx := types.NewTx(&types.LegacyTx{
 		Nonce:    0,
-		Gas:      defaultContractCreationGasLimit,
-		GasPrice: contractCreationGasPrice,
+		Gas:      gasLimit,
+		GasPrice: gasPrice,
 		To:       nil, // Contract creation transaction
 		Value:    big.NewInt(0),
-		Data:     byteCode,
+		Data:     data,
 		V:        vValue,
 		R:        rsValue,
 		S:        rsValue,
@@ -125,72 +213,113 @@ func ConstructKeylessTransaction(
 	// Recover the "sender" address of the transaction.
 	senderAddress, err := types.HomesteadSigner{}.Sender(contractCreationTx)
 	if err != nil {
-		return nil, common.Address{}, common.Address{}, errors.Wrap(
-			err,
-			"Failed to recover the sender address of transaction",
-		)
+		return nil, errors.Wrap(err, "Failed to recover the sender address of transaction")
 	}
 
-	// Serialize the raw transaction and sender address.
+	// Serialize the raw transaction.
 	contractCreationTxBytes, err := contractCreationTx.MarshalBinary()
 	if err != nil {
-		return nil, common.Address{}, common.Address{}, errors.Wrap(
-			err,
-			"Failed to serialize raw transaction",
-		)
+		return nil, errors.Wrap(err, "Failed to serialize raw transaction")
 	}
-	contractCreationTxString := "0x" + hex.EncodeToString(contractCreationTxBytes)
-	senderAddressString := senderAddress.Hex() // "0x" prepended by Hex() already.
 
 	// Derive the resulting contract address given that it will be deployed from the sender address using the nonce of 0.
 	contractAddress, err := DeriveEVMContractAddress(senderAddress, 0)
 	if err != nil {
-		return nil, common.Address{}, common.Address{}, errors.Wrap(
-			err,
-			"Failed to derive contract address",
-		)
+		return nil, errors.Wrap(err, "Failed to derive contract address")
 	}
-	contractAddressString := contractAddress.Hex() // "0x" prepended by Hex() already.
 
-	log.Println("Raw Teleporter Contract Creation Transaction:")
-	log.Println(contractCreationTxString)
-	log.Println("Teleporter Contract Keyless Deployer Address: ", senderAddressString)
-	log.Println("Teleporter Messenger Universal Contract Address: ", contractAddressString)
+	return &KeylessDeployment{
+		Transaction:      contractCreationTx,
+		TransactionBytes: contractCreationTxBytes,
+		DeployerAddress:  senderAddress,
+		ContractAddress:  contractAddress,
+		RequiredFunding:  contractCreationTx.Cost(),
+	}, nil
+}
 
-	if writeFile {
-		err = os.WriteFile(
-			contractCreationTxFileName,
-			[]byte(contractCreationTxString),
-			fs.ModePerm,
-		)
-		if err != nil {
-			return nil, common.Address{}, common.Address{}, errors.Wrap(
-				err,
-				"Failed to write to contract creation tx file",
-			)
-		}
+// EstimateContractCreationGas estimates the gas needed to deploy a contract with the given constructor
+// arguments, padded by 25% to account for differences in state between chains
+func EstimateContractCreationGas(
+	ctx context.Context,
+	estimator GasEstimator,
+	byteCode []byte,
+	constructorArgs []byte,
+) (uint64, error) {
+	data := make([]byte, 0, len(byteCode)+len(constructorArgs))
+	data = append(data, byteCode...)
+	data = append(data, constructorArgs...)
+	gas, err := estimator.EstimateGas(ctx, interfaces.CallMsg{Data: data})
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to estimate contract creation gas")
+	}
+	return gas + gas*contractCreationGasPaddingPercent/100, nil
+}
 
-		err = os.WriteFile(contractCreationAddrFileName, []byte(senderAddressString), fs.ModePerm)
+// Writes the raw transaction, deployer address, and contract address to the given writer
+func (d *KeylessDeployment) Write(w io.Writer) error {
+	_, err := fmt.Fprintf(
+		w,
+		"Raw Contract Creation Transaction:\n0x%s\nKeyless Deployer Address: %s\n"+
+			"Universal Contract Address: %s\nRequired Deployer Funding (wei): %s\n",
+		hex.EncodeToString(d.TransactionBytes),
+		d.DeployerAddress.Hex(),
+		d.ContractAddress.Hex(),
+		d.RequiredFunding,
+	)
+	return err
+}
+
+// Writes the raw transaction, deployer address, and contract address to the given files in the directory
+func (d *KeylessDeployment) WriteFiles(dir string, files KeylessDeploymentFiles) error {
+	contents := []struct {
+		fileName string
+		value    string
+		name     string
+	}{
+		{files.Transaction, "0x" + hex.EncodeToString(d.TransactionBytes), "contract creation tx"},
+		{files.DeployerAddress, d.DeployerAddress.Hex(), "deployer address"},
+		{files.ContractAddress, d.ContractAddress.Hex(), "contract address"},
+	}
+	for _, c := range contents {
+		err := os.WriteFile(filepath.Join(dir, c.fileName), []byte(c.value), fs.ModePerm)
 		if err != nil {
-			return nil, common.Address{}, common.Address{}, errors.Wrap(
-				err,
-				"Failed to write to deployer address file",
-			)
+			return errors.Wrapf(err, "Failed to write to %s file", c.name)
 		}
+	}
+	return nil
+}
 
-		err = os.WriteFile(
-			universalContractAddressFileName,
-			[]byte(contractAddressString),
-			fs.ModePerm,
-		)
-		if err != nil {
-			return nil, common.Address{}, common.Address{}, errors.Wrap(
-				err,
-				"Failed to write to contract address",
-			)
+// Constructs a keyless transaction using Nick's method for the bytecode in a forge build artifact
+// Optionally writes the transaction, deployer address, and contract address to file
+// Returns the transaction bytes, deployer address, and contract address
+func ConstructKeylessTransaction(
+	byteCodeFileName string,
+	writeFile bool,
+	contractCreationGasPrice *big.Int,
+) ([]byte, common.Address, common.Address, error) {
+	byteCode, err := ExtractByteCode(byteCodeFileName)
+	if err != nil {
+		return nil, common.Address{}, common.Address{}, err
+	}
+	deployment, err := ConstructKeylessDeployment(KeylessTransactionParams{
+		ByteCode: byteCode,
+		GasPrice: contractCreationGasPrice,
+	})
+	if err != nil {
+		return nil, common.Address{}, common.Address{}, err
+	}
+
+	log.Println("Raw Teleporter Contract Creation Transaction:")
+	log.Println("0x" + hex.EncodeToString(deployment.TransactionBytes))
+	log.Println("Teleporter Contract Keyless Deployer Address: ", deployment.DeployerAddress.Hex())
+	log.Println("Teleporter Messenger Universal Contract Address: ", deployment.ContractAddress.Hex())
+
+	if writeFile {
+		if err := deployment.WriteFiles(".", TeleporterKeylessDeploymentFiles); err != nil {
+			return nil, common.Address{}, common.Address{}, err
 		}
 	}
-	return contractCreationTxBytes, senderAddress, contractAddress, nil
+	return deployment.TransactionBytes, deployment.DeployerAddress, deployment.ContractAddress, nil
 }
 
 func GetDefaultContractCreationGasPrice() *big.Int {
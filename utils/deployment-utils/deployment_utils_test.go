@@ -1,9 +1,16 @@
 package utils
 
 import (
+	"bytes"
+	"context"
 	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
 
@@ -21,3 +28,104 @@ func TestGetDefaultContractCreationGasPrice(t *testing.T) {
 	gasPrice = GetDefaultContractCreationGasPrice()
 	require.Equal(t, newDefaultGasPrice, gasPrice)
 }
+
+type testGasEstimator struct {
+	gas  uint64
+	data []byte
+}
+
+func (e *testGasEstimator) EstimateGas(_ context.Context, call interfaces.CallMsg) (uint64, error) {
+	e.data = call.Data
+	return e.gas, nil
+}
+
+func TestConstructKeylessDeployment(t *testing.T) {
+	byteCode := []byte{0x60, 0x80, 0x60, 0x40}
+	constructorArgs := []byte{0x00, 0x01}
+
+	var tests = []struct {
+		name     string
+		params   KeylessTransactionParams
+		gasLimit uint64
+		gasPrice *big.Int
+		err      string
+	}{
+		{
+			name:     "defaults",
+			params:   KeylessTransactionParams{ByteCode: byteCode},
+			gasLimit: defaultContractCreationGasLimit,
+			gasPrice: GetDefaultContractCreationGasPrice(),
+		},
+		{
+			name: "configured gas",
+			params: KeylessTransactionParams{
+				ByteCode:        byteCode,
+				ConstructorArgs: constructorArgs,
+				GasLimit:        1_000_000,
+				GasPrice:        big.NewInt(100e9),
+			},
+			gasLimit: 1_000_000,
+			gasPrice: big.NewInt(100e9),
+		},
+		{
+			name: "empty bytecode",
+			err:  "Contract bytecode must not be empty.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment, err := ConstructKeylessDeployment(tt.params)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+
+			tx := new(types.Transaction)
+			require.NoError(t, tx.UnmarshalBinary(deployment.TransactionBytes))
+			require.Nil(t, tx.To())
+			require.Equal(t, tt.gasLimit, tx.Gas())
+			require.Equal(t, tt.gasPrice, tx.GasPrice())
+			require.Equal(t, append(append([]byte{}, byteCode...), tt.params.ConstructorArgs...), tx.Data())
+			require.Equal(t, new(big.Int).Mul(new(big.Int).SetUint64(tt.gasLimit), tt.gasPrice), deployment.RequiredFunding)
+
+			sender, err := types.HomesteadSigner{}.Sender(tx)
+			require.NoError(t, err)
+			require.Equal(t, sender, deployment.DeployerAddress)
+			contractAddress, err := DeriveEVMContractAddress(sender, 0)
+			require.NoError(t, err)
+			require.Equal(t, contractAddress, deployment.ContractAddress)
+		})
+	}
+}
+
+func TestKeylessDeploymentWriteFiles(t *testing.T) {
+	deployment, err := ConstructKeylessDeployment(KeylessTransactionParams{ByteCode: []byte{0x60, 0x80}})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, deployment.WriteFiles(dir, TeleporterKeylessDeploymentFiles))
+	for fileName, expected := range map[string]string{
+		contractCreationTxFileName:       "0x" + common.Bytes2Hex(deployment.TransactionBytes),
+		contractCreationAddrFileName:     deployment.DeployerAddress.Hex(),
+		universalContractAddressFileName: deployment.ContractAddress.Hex(),
+	} {
+		b, err := os.ReadFile(filepath.Join(dir, fileName))
+		require.NoError(t, err)
+		require.Equal(t, expected, string(b))
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, deployment.Write(&buf))
+	require.Contains(t, buf.String(), deployment.ContractAddress.Hex())
+	require.Contains(t, buf.String(), deployment.RequiredFunding.String())
+}
+
+func TestEstimateContractCreationGas(t *testing.T) {
+	estimator := &testGasEstimator{gas: 1_000_000}
+	gas, err := EstimateContractCreationGas(context.Background(), estimator, []byte{0x60, 0x80}, []byte{0x01})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1_250_000), gas)
+	require.Equal(t, []byte{0x60, 0x80, 0x01}, estimator.data)
+}
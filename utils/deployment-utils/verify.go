@@ -0,0 +1,125 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// BytecodeStatus is the result of comparing the code deployed at an address against a build artifact
+type BytecodeStatus uint8
+
+const (
+	BytecodeMatch BytecodeStatus = iota
+	BytecodeMismatch
+	BytecodeMissing
+)
+
+func (s BytecodeStatus) String() string {
+	switch s {
+	case BytecodeMatch:
+		return "match"
+	case BytecodeMismatch:
+		return "mismatch"
+	default:
+		return "missing"
+	}
+}
+
+// CodeRange is a range of bytes in a contract's code
+type CodeRange struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// DeployedByteCode is the runtime bytecode of a contract from a forge build artifact
+type DeployedByteCode struct {
+	Code []byte
+	// Ranges of the code holding immutable variables, which are only set when the contract is deployed
+	ImmutableReferences []CodeRange
+}
+
+// CodeReader reads the code deployed at an address, such as an ethclient.Client
+type CodeReader interface {
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+}
+
+// ExtractDeployedByteCode returns the runtime bytecode from a forge build artifact
+func ExtractDeployedByteCode(byteCodeFileName string) (*DeployedByteCode, error) {
+	byteCodeJSON, err := readByteCodeFile(byteCodeFileName)
+	if err != nil {
+		return nil, err
+	}
+	code, err := decodeByteCode(byteCodeJSON.DeployedByteCode.Object)
+	if err != nil {
+		return nil, err
+	}
+	deployed := &DeployedByteCode{Code: code}
+	for _, ranges := range byteCodeJSON.DeployedByteCode.ImmutableReferences {
+		for _, r := range ranges {
+			if r.Start < 0 || r.Length < 0 || r.Start+r.Length > len(code) {
+				return nil, errors.New("Immutable reference is out of the range of the deployed bytecode.")
+			}
+			deployed.ImmutableReferences = append(deployed.ImmutableReferences, r)
+		}
+	}
+	return deployed, nil
+}
+
+// StripMetadata removes the CBOR encoded metadata that the Solidity compiler appends to the
+// runtime bytecode, which includes a hash of the contract's source files and compiler settings.
+// The last two bytes of the code are the length of the metadata. The code is returned unchanged
+// if it does not end with metadata.
+func StripMetadata(code []byte) []byte {
+	if len(code) < 2 {
+		return code
+	}
+	metadataLength := int(code[len(code)-2])<<8 | int(code[len(code)-1])
+	start := len(code) - 2 - metadataLength
+	// The metadata is a CBOR map, which has major type 5.
+	if metadataLength == 0 || start < 0 || code[start]>>5 != 5 {
+		return code
+	}
+	return code[:start]
+}
+
+// Matches returns whether the given deployed code matches the runtime bytecode, ignoring the
+// metadata and the values of immutable variables
+func (d *DeployedByteCode) Matches(code []byte) bool {
+	expected := bytes.Clone(d.Code)
+	actual := bytes.Clone(code)
+	for _, r := range d.ImmutableReferences {
+		if r.Start+r.Length > len(actual) {
+			return false
+		}
+		clear(expected[r.Start : r.Start+r.Length])
+		clear(actual[r.Start : r.Start+r.Length])
+	}
+	return bytes.Equal(StripMetadata(expected), StripMetadata(actual))
+}
+
+// VerifyDeployedByteCode compares the code deployed at the given address against the runtime bytecode
+func VerifyDeployedByteCode(
+	ctx context.Context,
+	client CodeReader,
+	address common.Address,
+	expected *DeployedByteCode,
+) (BytecodeStatus, error) {
+	code, err := client.CodeAt(ctx, address, nil)
+	if err != nil {
+		return BytecodeMissing, errors.Wrap(err, "Failed to get deployed code")
+	}
+	if len(code) == 0 {
+		return BytecodeMissing, nil
+	}
+	if !expected.Matches(code) {
+		return BytecodeMismatch, nil
+	}
+	return BytecodeMatch, nil
+}
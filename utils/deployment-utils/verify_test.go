@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// Runtime code followed by metadata of the form {"solc": 0x00081e}, and its length
+var (
+	testRuntimeCode = []byte{0x60, 0x80, 0x60, 0x40, 0x52, 0x7f, 0x00, 0x00, 0x00, 0x00}
+	testMetadata    = []byte{0xa1, 0x64, 's', 'o', 'l', 'c', 0x43, 0x00, 0x08, 0x1e, 0x00, 0x0a}
+)
+
+type testCodeReader map[common.Address][]byte
+
+func (r testCodeReader) CodeAt(_ context.Context, account common.Address, _ *big.Int) ([]byte, error) {
+	return r[account], nil
+}
+
+func TestStripMetadata(t *testing.T) {
+	code := append(append([]byte{}, testRuntimeCode...), testMetadata...)
+	require.Equal(t, testRuntimeCode, StripMetadata(code))
+
+	// Code that does not end with a CBOR map is unchanged.
+	require.Equal(t, testRuntimeCode, StripMetadata(testRuntimeCode))
+	require.Equal(t, []byte{0x00}, StripMetadata([]byte{0x00}))
+}
+
+func TestVerifyDeployedByteCode(t *testing.T) {
+	byteCodeFile := filepath.Join(t.TempDir(), "Contract.json")
+	require.NoError(t, os.WriteFile(byteCodeFile, []byte(`{
+		"bytecode": {"object": "0x6080"},
+		"deployedBytecode": {
+			"object": "0x60806040527f00000000a164736f6c634300081e000a",
+			"immutableReferences": {"42": [{"start": 6, "length": 4}]}
+		}
+	}`), 0o600))
+	expected, err := ExtractDeployedByteCode(byteCodeFile)
+	require.NoError(t, err)
+	require.Equal(t, append(append([]byte{}, testRuntimeCode...), testMetadata...), expected.Code)
+	require.Equal(t, []CodeRange{{Start: 6, Length: 4}}, expected.ImmutableReferences)
+
+	otherMetadata := append([]byte{}, testMetadata...)
+	otherMetadata[9] = 0x1f
+	withImmutable := append([]byte{}, testRuntimeCode...)
+	copy(withImmutable[6:], []byte{0xde, 0xad, 0xbe, 0xef})
+	modified := append([]byte{}, testRuntimeCode...)
+	modified[0] = 0x61
+
+	var (
+		matchAddress     = common.HexToAddress("0x01")
+		immutableAddress = common.HexToAddress("0x02")
+		mismatchAddress  = common.HexToAddress("0x03")
+		missingAddress   = common.HexToAddress("0x04")
+	)
+	client := testCodeReader{
+		matchAddress:     append(append([]byte{}, testRuntimeCode...), otherMetadata...),
+		immutableAddress: append(withImmutable, testMetadata...),
+		mismatchAddress:  append(modified, testMetadata...),
+	}
+
+	var tests = []struct {
+		name    string
+		address common.Address
+		status  BytecodeStatus
+	}{
+		{"different metadata", matchAddress, BytecodeMatch},
+		{"immutable values", immutableAddress, BytecodeMatch},
+		{"different code", mismatchAddress, BytecodeMismatch},
+		{"no code", missingAddress, BytecodeMissing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := VerifyDeployedByteCode(context.Background(), client, tt.address, expected)
+			require.NoError(t, err)
+			require.Equal(t, tt.status, status)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ABI of the ERC20 functions used to approve fee tokens, so that any ERC20 token can be used without
+// depending on the bindings of a particular token implementation
+const erc20ABIJSON = `[
+	{
+		"type": "function",
+		"name": "allowance",
+		"stateMutability": "view",
+		"inputs": [{"name": "owner", "type": "address"}, {"name": "spender", "type": "address"}],
+		"outputs": [{"name": "", "type": "uint256"}]
+	},
+	{
+		"type": "function",
+		"name": "approve",
+		"stateMutability": "nonpayable",
+		"inputs": [{"name": "spender", "type": "address"}, {"name": "amount", "type": "uint256"}],
+		"outputs": [{"name": "", "type": "bool"}]
+	}
+]`
+
+var erc20ABI abi.ABI
+
+func init() {
+	var err error
+	erc20ABI, err = abi.JSON(strings.NewReader(erc20ABIJSON))
+	if err != nil {
+		panic(fmt.Sprintf("failed to parse ERC20 ABI: %v", err))
+	}
+}
+
+// NewERC20 returns a binding to the allowance and approve functions of the ERC20 token at the given address
+func NewERC20(address common.Address, backend bind.ContractBackend) *bind.BoundContract {
+	return bind.NewBoundContract(address, erc20ABI, backend, backend, backend)
+}
+
+// Allowance returns the amount of the token that the spender is allowed to spend on behalf of the owner
+func Allowance(
+	opts *bind.CallOpts,
+	token *bind.BoundContract,
+	owner common.Address,
+	spender common.Address,
+) (*big.Int, error) {
+	var out []interface{}
+	if err := token.Call(opts, &out, "allowance", owner, spender); err != nil {
+		return nil, err
+	}
+	return *abi.ConvertType(out[0], new(*big.Int)).(**big.Int), nil
+}
+
+// Approve sends a transaction allowing the spender to spend the given amount of the token on behalf of the
+// sender of the transaction
+func Approve(
+	opts *bind.TransactOpts,
+	token *bind.BoundContract,
+	spender common.Address,
+	amount *big.Int,
+) (*types.Transaction, error) {
+	return token.Transact(opts, "approve", spender, amount)
+}
+
+// PackApprove packs the inputs to form a call to the approve function
+func PackApprove(spender common.Address, amount *big.Int) ([]byte, error) {
+	return erc20ABI.Pack("approve", spender, amount)
+}
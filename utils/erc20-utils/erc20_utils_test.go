@@ -0,0 +1,62 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind/backends"
+	"github.com/ava-labs/subnet-evm/core"
+	exampleerc20 "github.com/ava-labs/teleporter/abi-bindings/go/Mocks/ExampleERC20"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowanceAndApprove(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{
+		owner: {Balance: new(big.Int).Lsh(big.NewInt(1), 100)},
+	}, 30_000_000)
+	t.Cleanup(func() { backend.Close() })
+
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	require.NoError(t, err)
+	tokenAddress, _, _, err := exampleerc20.DeployExampleERC20(opts, backend)
+	require.NoError(t, err)
+	backend.Commit(true)
+
+	spender := common.HexToAddress("0x0123456789012345678901234567890123456789")
+	token := NewERC20(tokenAddress, backend)
+	callOpts := &bind.CallOpts{Context: context.Background()}
+
+	allowance, err := Allowance(callOpts, token, owner, spender)
+	require.NoError(t, err)
+	require.Zero(t, allowance.Sign())
+
+	tx, err := Approve(opts, token, spender, big.NewInt(100))
+	require.NoError(t, err)
+	backend.Commit(true)
+	receipt, err := backend.TransactionReceipt(context.Background(), tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), receipt.Status)
+
+	allowance, err = Allowance(callOpts, token, owner, spender)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100), allowance)
+
+	// The packed call data must match the token's own binding
+	data, err := PackApprove(spender, big.NewInt(100))
+	require.NoError(t, err)
+	tokenABI, err := exampleerc20.ExampleERC20MetaData.GetAbi()
+	require.NoError(t, err)
+	expected, err := tokenABI.Pack("approve", spender, big.NewInt(100))
+	require.NoError(t, err)
+	require.Equal(t, expected, data)
+}
@@ -4,14 +4,26 @@
 package utils
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
+var (
+	// ErrMessageHashNotFound is returned when the TeleporterMessenger contract stores no hash for a message
+	ErrMessageHashNotFound = errors.New("message hash not found")
+	// ErrMessageHashMismatch is returned when a message does not match the hash stored by the
+	// TeleporterMessenger contract, meaning it differs from the message that was sent or received
+	ErrMessageHashMismatch = errors.New("message does not match the stored message hash")
+)
+
 var (
 	uint256Ty abi.Type
 	bytes32Ty abi.Type
@@ -49,3 +61,62 @@ func CalculateMessageID(
 
 	return ids.ID(crypto.Keccak256Hash(bytes)), nil
 }
+
+// CalculateMessageHash returns the hash the TeleporterMessenger contract stores for a message, which is
+// the keccak256 hash of its ABI encoding. The hash is stored by the source chain for messages that can
+// be resent, and by the destination chain for messages whose execution failed.
+func CalculateMessageHash(message teleportermessenger.TeleporterMessage) (common.Hash, error) {
+	messageBytes, err := teleportermessenger.PackTeleporterMessage(message)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(messageBytes), nil
+}
+
+// VerifyMessageHash checks that the message matches the hash stored by the TeleporterMessenger contract.
+// An empty stored hash means the contract stores no hash for the message.
+func VerifyMessageHash(message teleportermessenger.TeleporterMessage, storedHash common.Hash) error {
+	if storedHash == (common.Hash{}) {
+		return ErrMessageHashNotFound
+	}
+	messageHash, err := CalculateMessageHash(message)
+	if err != nil {
+		return err
+	}
+	if messageHash != storedHash {
+		return fmt.Errorf("%w: expected %s, got %s", ErrMessageHashMismatch, storedHash.Hex(), messageHash.Hex())
+	}
+	return nil
+}
+
+// VerifySentMessage checks that a message sent from the messenger's chain matches the message hash it
+// stores, so that the message can be resent with retrySendCrossChainMessage. The hash is deleted once
+// the receipt for the message is returned.
+func VerifySentMessage(
+	opts *bind.CallOpts,
+	messenger *teleportermessenger.TeleporterMessengerCaller,
+	messageID ids.ID,
+	message teleportermessenger.TeleporterMessage,
+) error {
+	storedHash, err := messenger.GetMessageHash(opts, messageID)
+	if err != nil {
+		return err
+	}
+	return VerifyMessageHash(message, storedHash)
+}
+
+// VerifyFailedMessage checks that a message received by the messenger's chain matches the hash it stores
+// for the message's failed execution, so that the execution can be retried with retryMessageExecution.
+// The hash is deleted once the message is successfully executed.
+func VerifyFailedMessage(
+	opts *bind.CallOpts,
+	messenger *teleportermessenger.TeleporterMessengerCaller,
+	messageID ids.ID,
+	message teleportermessenger.TeleporterMessage,
+) error {
+	storedHash, err := messenger.ReceivedFailedMessageHashes(opts, messageID)
+	if err != nil {
+		return err
+	}
+	return VerifyMessageHash(message, storedHash)
+}
@@ -4,11 +4,17 @@
 package utils
 
 import (
+	"context"
+	"errors"
 	"math/big"
 	"testing"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 )
 
@@ -74,3 +80,98 @@ func TestCalculateMessageID(t *testing.T) {
 		})
 	}
 }
+
+func createTestTeleporterMessage() teleportermessenger.TeleporterMessage {
+	return teleportermessenger.TeleporterMessage{
+		MessageNonce:            big.NewInt(1),
+		OriginSenderAddress:     common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567"),
+		DestinationBlockchainID: ids.ID{1, 2, 3, 4},
+		DestinationAddress:      common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567"),
+		RequiredGasLimit:        big.NewInt(2),
+		AllowedRelayerAddresses: []common.Address{},
+		Receipts:                []teleportermessenger.TeleporterMessageReceipt{},
+		Message:                 []byte{1, 2, 3, 4},
+	}
+}
+
+func TestVerifyMessageHash(t *testing.T) {
+	message := createTestTeleporterMessage()
+	messageBytes, err := teleportermessenger.PackTeleporterMessage(message)
+	require.NoError(t, err)
+	messageHash, err := CalculateMessageHash(message)
+	require.NoError(t, err)
+	require.Equal(t, crypto.Keccak256Hash(messageBytes), messageHash)
+
+	altered := createTestTeleporterMessage()
+	altered.Message = []byte{4, 3, 2, 1}
+
+	require.NoError(t, VerifyMessageHash(message, messageHash))
+	require.True(t, errors.Is(VerifyMessageHash(altered, messageHash), ErrMessageHashMismatch))
+	require.True(t, errors.Is(VerifyMessageHash(message, common.Hash{}), ErrMessageHashNotFound))
+}
+
+// testCaller is a contract caller that returns the same result for every call
+type testCaller struct {
+	result []byte
+}
+
+func (c *testCaller) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (c *testCaller) CallContract(context.Context, interfaces.CallMsg, *big.Int) ([]byte, error) {
+	return c.result, nil
+}
+
+func TestVerifyStoredMessageHash(t *testing.T) {
+	message := createTestTeleporterMessage()
+	messageHash, err := CalculateMessageHash(message)
+	require.NoError(t, err)
+	altered := createTestTeleporterMessage()
+	altered.RequiredGasLimit = big.NewInt(3)
+
+	var (
+		tests = []struct {
+			name       string
+			storedHash common.Hash
+			message    teleportermessenger.TeleporterMessage
+			expected   error
+		}{
+			{
+				name:       "matching message",
+				storedHash: messageHash,
+				message:    message,
+			},
+			{
+				name:       "altered message",
+				storedHash: messageHash,
+				message:    altered,
+				expected:   ErrMessageHashMismatch,
+			},
+			{
+				name:     "no stored hash",
+				message:  message,
+				expected: ErrMessageHashNotFound,
+			},
+		}
+	)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// getMessageHash and receivedFailedMessageHashes have the same output encoding
+			result, err := teleportermessenger.PackGetMessageHashOutput(test.storedHash)
+			require.NoError(t, err)
+			messenger, err := teleportermessenger.NewTeleporterMessengerCaller(
+				teleporterMessengerAddress,
+				&testCaller{result: result},
+			)
+			require.NoError(t, err)
+
+			messageID := ids.GenerateTestID()
+			err = VerifySentMessage(&bind.CallOpts{}, messenger, messageID, test.message)
+			require.True(t, errors.Is(err, test.expected), "unexpected error %v", err)
+			err = VerifyFailedMessage(&bind.CallOpts{}, messenger, messageID, test.message)
+			require.True(t, errors.Is(err, test.expected), "unexpected error %v", err)
+		})
+	}
+}
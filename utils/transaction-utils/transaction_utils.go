@@ -0,0 +1,515 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/eth/tracers"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	predicateutils "github.com/ava-labs/subnet-evm/predicate"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/TeleporterMessenger"
+	teleporterregistry "github.com/ava-labs/teleporter/abi-bindings/go/Teleporter/upgrades/TeleporterRegistry"
+	deploymentUtils "github.com/ava-labs/teleporter/utils/deployment-utils"
+	gasUtils "github.com/ava-labs/teleporter/utils/gas-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
+)
+
+const (
+	NativeTransferGas               uint64 = 21_000
+	DefaultTeleporterTransactionGas uint64 = 300_000
+	AddProtocolVersionGas           uint64 = 500_000
+
+	// Number of signers assumed when calculating the gas limit of a retried message execution
+	retryMessageExecutionNumSigners = 10
+
+	receiptQueryInterval     = 200 * time.Millisecond
+	blockHeightQueryInterval = 2 * time.Second
+	waitMinedTimeout         = 20 * time.Second
+)
+
+var (
+	ErrNoContractCode = errors.New("no code at contract address")
+	// ErrTransactionFailed is returned when a transaction is accepted but reverted
+	ErrTransactionFailed = errors.New("transaction failed")
+)
+
+// Backend is the connection to a chain used to create, send and wait for transactions, such as an
+// ethclient.Client
+type Backend interface {
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	EstimateGas(ctx context.Context, call interfaces.CallMsg) (uint64, error)
+	EstimateBaseFee(ctx context.Context) (*big.Int, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+//
+// Transaction creation functions
+//
+
+// Constructs a transaction to call sendCrossChainMessage
+// Returns the signed transaction.
+func CreateSendCrossChainMessageTransaction(
+	ctx context.Context,
+	client ethclient.Client,
+	chainID *big.Int,
+	input teleportermessenger.TeleporterMessageInput,
+	senderKey *ecdsa.PrivateKey,
+	teleporterContractAddress common.Address,
+) (*types.Transaction, error) {
+	data, err := teleportermessenger.PackSendCrossChainMessage(input)
+	if err != nil {
+		return nil, err
+	}
+	return createTransaction(
+		ctx, client, chainID, senderKey, teleporterContractAddress, DefaultTeleporterTransactionGas, nil, data,
+	)
+}
+
+// Constructs a transaction to call retryMessageExecution
+// Returns the signed transaction.
+func CreateRetryMessageExecutionTransaction(
+	ctx context.Context,
+	client ethclient.Client,
+	chainID *big.Int,
+	sourceBlockchainID ids.ID,
+	message teleportermessenger.TeleporterMessage,
+	senderKey *ecdsa.PrivateKey,
+	teleporterContractAddress common.Address,
+) (*types.Transaction, error) {
+	data, err := teleportermessenger.PackRetryMessageExecution(sourceBlockchainID, message)
+	if err != nil {
+		return nil, err
+	}
+	gasLimit, err := gasUtils.CalculateReceiveMessageGasLimit(retryMessageExecutionNumSigners, message.RequiredGasLimit)
+	if err != nil {
+		return nil, err
+	}
+	return createTransaction(ctx, client, chainID, senderKey, teleporterContractAddress, gasLimit, nil, data)
+}
+
+// Constructs a transaction to call receiveCrossChainMessage with the signed Warp message in its predicate
+// Returns the signed transaction.
+func CreateReceiveCrossChainMessageTransaction(
+	ctx context.Context,
+	logger logging.Logger,
+	client ethclient.Client,
+	chainID *big.Int,
+	signedMessage *avalancheWarp.Message,
+	requiredGasLimit *big.Int,
+	teleporterContractAddress common.Address,
+	senderKey *ecdsa.PrivateKey,
+) (*types.Transaction, error) {
+	// Construct the transaction to send the Warp message to the destination chain
+	logger.Info("Constructing receiveCrossChainMessage transaction for the destination chain")
+	numSigners, err := signedMessage.Signature.NumSigners()
+	if err != nil {
+		return nil, err
+	}
+	gasLimit, err := gasUtils.CalculateReceiveMessageGasLimit(numSigners, requiredGasLimit)
+	if err != nil {
+		return nil, err
+	}
+	callData, err := teleportermessenger.PackReceiveCrossChainMessage(0, PrivateKeyToAddress(senderKey))
+	if err != nil {
+		return nil, err
+	}
+	return createPredicateTransaction(
+		ctx, client, chainID, senderKey, teleporterContractAddress, gasLimit, callData, signedMessage,
+	)
+}
+
+// Constructs a transaction to call addProtocolVersion with the signed Warp message in its predicate
+// Returns the signed transaction.
+func CreateAddProtocolVersionTransaction(
+	ctx context.Context,
+	logger logging.Logger,
+	client ethclient.Client,
+	chainID *big.Int,
+	signedMessage *avalancheWarp.Message,
+	teleporterRegistryAddress common.Address,
+	senderKey *ecdsa.PrivateKey,
+) (*types.Transaction, error) {
+	// Construct the transaction to send the Warp message to the destination chain
+	logger.Info("Constructing addProtocolVersion transaction for the destination chain")
+	callData, err := teleporterregistry.PackAddProtocolVersion(0)
+	if err != nil {
+		return nil, err
+	}
+	return createPredicateTransaction(
+		ctx, client, chainID, senderKey, teleporterRegistryAddress, AddProtocolVersionGas, callData, signedMessage,
+	)
+}
+
+// Constructs a transaction transferring the native token
+// Returns the signed transaction.
+func CreateNativeTransferTransaction(
+	ctx context.Context,
+	client ethclient.Client,
+	chainID *big.Int,
+	fromKey *ecdsa.PrivateKey,
+	recipient common.Address,
+	amount *big.Int,
+) (*types.Transaction, error) {
+	return createTransaction(ctx, client, chainID, fromKey, recipient, NativeTransferGas, amount, nil)
+}
+
+func createTransaction(
+	ctx context.Context,
+	client ethclient.Client,
+	chainID *big.Int,
+	senderKey *ecdsa.PrivateKey,
+	to common.Address,
+	gasLimit uint64,
+	value *big.Int,
+	data []byte,
+) (*types.Transaction, error) {
+	tx, err := NewTransaction(ctx, client, chainID, PrivateKeyToAddress(senderKey), &to, gasLimit, value, data)
+	if err != nil {
+		return nil, err
+	}
+	return SignTransaction(tx, senderKey, chainID)
+}
+
+// Constructs an unsigned dynamic fee transaction from the sender calling the given address, or creating a
+// contract if it is nil. The gas fee caps and nonce are calculated with CalculateTxParams, and the gas
+// limit is estimated if it is zero.
+func NewTransaction(
+	ctx context.Context,
+	backend Backend,
+	chainID *big.Int,
+	sender common.Address,
+	to *common.Address,
+	gasLimit uint64,
+	value *big.Int,
+	data []byte,
+) (*types.Transaction, error) {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	if gasLimit == 0 {
+		var err error
+		gasLimit, err = backend.EstimateGas(ctx, interfaces.CallMsg{
+			From:  sender,
+			To:    to,
+			Value: value,
+			Data:  data,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate gas: %w", err)
+		}
+	}
+	gasFeeCap, gasTipCap, nonce, err := CalculateTxParams(ctx, backend, sender)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		To:        to,
+		Gas:       gasLimit,
+		GasFeeCap: gasFeeCap,
+		GasTipCap: gasTipCap,
+		Value:     value,
+		Data:      data,
+	}), nil
+}
+
+func createPredicateTransaction(
+	ctx context.Context,
+	client ethclient.Client,
+	chainID *big.Int,
+	senderKey *ecdsa.PrivateKey,
+	to common.Address,
+	gasLimit uint64,
+	callData []byte,
+	signedMessage *avalancheWarp.Message,
+) (*types.Transaction, error) {
+	gasFeeCap, gasTipCap, nonce, err := CalculateTxParams(ctx, client, PrivateKeyToAddress(senderKey))
+	if err != nil {
+		return nil, err
+	}
+	tx := predicateutils.NewPredicateTx(
+		chainID,
+		nonce,
+		&to,
+		gasLimit,
+		gasFeeCap,
+		gasTipCap,
+		big.NewInt(0),
+		callData,
+		types.AccessList{},
+		warp.ContractAddress,
+		signedMessage.Bytes(),
+	)
+	return SignTransaction(tx, senderKey, chainID)
+}
+
+// Signs a transaction using the provided key for the specified chainID
+func SignTransaction(tx *types.Transaction, key *ecdsa.PrivateKey, chainID *big.Int) (*types.Transaction, error) {
+	txSigner := types.LatestSignerForChainID(chainID)
+	return types.SignTx(tx, txSigner, key)
+}
+
+// Returns the gasFeeCap, gasTipCap, and nonce the be used when constructing a transaction from fundedAddress
+func CalculateTxParams(
+	ctx context.Context,
+	backend Backend,
+	fundedAddress common.Address,
+) (*big.Int, *big.Int, uint64, error) {
+	baseFee, err := backend.EstimateBaseFee(ctx)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	gasTipCap, err := backend.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	nonce, err := backend.NonceAt(ctx, fundedAddress, nil)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	gasFeeCap := new(big.Int).Mul(baseFee, big.NewInt(gasUtils.BaseFeeFactor))
+	gasFeeCap.Add(gasFeeCap, big.NewInt(gasUtils.MaxPriorityFeePerGas))
+
+	return gasFeeCap, gasTipCap, nonce, nil
+}
+
+func PrivateKeyToAddress(k *ecdsa.PrivateKey) common.Address {
+	return crypto.PubkeyToAddress(k.PublicKey)
+}
+
+//
+// Transaction sending functions
+//
+
+// Sends a tx, and waits for it to be mined.
+// The receipt is returned regardless of the status of the transaction.
+func SendTransactionAndWait(
+	ctx context.Context,
+	logger logging.Logger,
+	backend Backend,
+	tx *types.Transaction,
+) (*types.Receipt, error) {
+	if err := backend.SendTransaction(ctx, tx); err != nil {
+		return nil, err
+	}
+	return WaitMined(ctx, logger, backend, tx.Hash())
+}
+
+// Sends a tx, and waits for it to be mined.
+// Returns ErrTransactionFailed along with the receipt if the transaction was reverted.
+func SendTransactionAndWaitForSuccess(
+	ctx context.Context,
+	logger logging.Logger,
+	backend Backend,
+	tx *types.Transaction,
+) (*types.Receipt, error) {
+	receipt, err := SendTransactionAndWait(ctx, logger, backend, tx)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return receipt, fmt.Errorf("%w: %s", ErrTransactionFailed, receipt.TxHash.Hex())
+	}
+	return receipt, nil
+}
+
+// WaitMined waits for tx to be mined on the blockchain.
+// It stops waiting when the context is canceled.
+// Takes a tx hash instead of the full tx in the subnet-evm version of this function.
+// Copied and modified from https://github.com/ava-labs/subnet-evm/blob/v0.6.0-fuji/accounts/abi/bind/util.go#L42
+func WaitMined(
+	ctx context.Context,
+	logger logging.Logger,
+	backend Backend,
+	txHash common.Hash,
+) (*types.Receipt, error) {
+	cctx, cancel := context.WithTimeout(ctx, waitMinedTimeout)
+	defer cancel()
+
+	receipt, err := waitForTransactionReceipt(cctx, logger, backend, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check that the block height endpoint returns a block height as high as the block number that the transaction was
+	// included in. This is to workaround the issue where multiple nodes behind a public RPC endpoint see
+	// transactions/blocks at different points in time. Ideally, all nodes in the network should have seen this block
+	// and transaction before returning from WaitMined. The block height endpoint of public RPC endpoints is
+	// configured to return the lowest value currently returned by any node behind the load balancer, so waiting for
+	// it to be at least as high as the block height specified in the receipt should provide a relatively strong
+	// indication that the transaction has been seen widely throughout the network.
+	err = waitForBlockHeight(cctx, logger, backend, receipt.BlockNumber.Uint64())
+	if err != nil {
+		return nil, err
+	}
+
+	return receipt, nil
+}
+
+// Polls for a transaction receipt of the given txHash on each queryTicker tick until
+// either a transaction receipt returned, or the context is cancelled or expired.
+func waitForTransactionReceipt(
+	cctx context.Context,
+	logger logging.Logger,
+	backend Backend,
+	txHash common.Hash,
+) (*types.Receipt, error) {
+	queryTicker := time.NewTicker(receiptQueryInterval)
+	defer queryTicker.Stop()
+	for {
+		receipt, err := backend.TransactionReceipt(cctx, txHash)
+		if err == nil {
+			return receipt, nil
+		}
+
+		if errors.Is(err, interfaces.NotFound) {
+			logger.Debug("Transaction not yet mined", zap.Stringer("txHash", txHash))
+		} else {
+			logger.Error("Receipt retrieval failed", zap.Stringer("txHash", txHash), zap.Error(err))
+			return nil, err
+		}
+
+		// Wait for the next round.
+		select {
+		case <-cctx.Done():
+			return nil, cctx.Err()
+		case <-queryTicker.C:
+		}
+	}
+}
+
+// Polls for the eth_blockNumber endpoint for the latest blockheight on each queryTicker tick until
+// either the returned height is greater than or equal to the expectedBlockNumber, or the context
+// is cancelled or expired.
+func waitForBlockHeight(
+	cctx context.Context,
+	logger logging.Logger,
+	backend Backend,
+	expectedBlockNumber uint64,
+) error {
+	queryTicker := time.NewTicker(blockHeightQueryInterval)
+	defer queryTicker.Stop()
+	for {
+		currentBlockNumber, err := backend.BlockNumber(cctx)
+		if err != nil {
+			return err
+		}
+
+		if currentBlockNumber >= expectedBlockNumber {
+			return nil
+		}
+		logger.Info("Waiting for block height where transaction was included",
+			zap.Uint64("blockNumber", expectedBlockNumber))
+
+		// Wait for the next round.
+		select {
+		case <-cctx.Done():
+			return cctx.Err()
+		case <-queryTicker.C:
+		}
+	}
+}
+
+// Returns the first log in 'logs' that is successfully parsed by 'parser'
+func GetEventFromLogs[T any](logs []*types.Log, parser func(log types.Log) (T, error)) (T, error) {
+	for _, log := range logs {
+		event, err := parser(*log)
+		if err == nil {
+			return event, nil
+		}
+	}
+	return *new(T), fmt.Errorf("failed to find %T event in receipt logs", *new(T))
+}
+
+// Returns the call trace of the transaction as JSON
+func TraceTransaction(ctx context.Context, client ethclient.Client, txHash common.Hash) (string, error) {
+	var result interface{}
+	ct := "callTracer"
+	err := client.Client().CallContext(
+		ctx, &result, "debug_traceTransaction", txHash.String(), tracers.TraceConfig{Tracer: &ct},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	jsonStr, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonStr), nil
+}
+
+// Deploys the contract in the forge build artifact with the given constructor arguments, and waits for the
+// transaction to be accepted. Returns the address of the contract and the receipt of the transaction.
+func DeployContract(
+	ctx context.Context,
+	logger logging.Logger,
+	client ethclient.Client,
+	chainID *big.Int,
+	byteCodeFileName string,
+	deployerKey *ecdsa.PrivateKey,
+	contractABI *abi.ABI,
+	constructorArgs ...interface{},
+) (common.Address, *types.Receipt, error) {
+	byteCode, err := deploymentUtils.ExtractByteCode(byteCodeFileName)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	if len(byteCode) == 0 {
+		return common.Address{}, nil, fmt.Errorf("no bytecode in %s", byteCodeFileName)
+	}
+	transactor, err := bind.NewKeyedTransactorWithChainID(deployerKey, chainID)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	contractAddress, tx, _, err := bind.DeployContract(
+		transactor,
+		*contractABI,
+		byteCode,
+		client,
+		constructorArgs...,
+	)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	// Wait for transaction, then check code was deployed
+	receipt, err := WaitMined(ctx, logger, client, tx.Hash())
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return common.Address{}, receipt, fmt.Errorf("contract deployment transaction %s failed", tx.Hash().Hex())
+	}
+	code, err := client.CodeAt(ctx, contractAddress, nil)
+	if err != nil {
+		return common.Address{}, receipt, err
+	}
+	if len(code) == 0 {
+		return common.Address{}, receipt, fmt.Errorf("%w %s", ErrNoContractCode, contractAddress.Hex())
+	}
+	return contractAddress, receipt, nil
+}
@@ -0,0 +1,138 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	gasUtils "github.com/ava-labs/teleporter/utils/gas-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignTransaction(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainID := big.NewInt(43112)
+	to := common.HexToAddress("0x01")
+
+	signedTx, err := SignTransaction(types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		To:        &to,
+		Gas:       NativeTransferGas,
+		GasFeeCap: big.NewInt(1),
+		GasTipCap: big.NewInt(1),
+		Value:     big.NewInt(1),
+	}), key, chainID)
+	require.NoError(t, err)
+
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signedTx)
+	require.NoError(t, err)
+	require.Equal(t, PrivateKeyToAddress(key), sender)
+}
+
+func TestGetEventFromLogs(t *testing.T) {
+	logs := []*types.Log{
+		{Address: common.HexToAddress("0x01")},
+		{Address: common.HexToAddress("0x02")},
+	}
+	parser := func(address common.Address) func(log types.Log) (common.Address, error) {
+		return func(log types.Log) (common.Address, error) {
+			if log.Address != address {
+				return common.Address{}, errors.New("unexpected log")
+			}
+			return log.Address, nil
+		}
+	}
+
+	event, err := GetEventFromLogs(logs, parser(common.HexToAddress("0x02")))
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0x02"), event)
+
+	_, err = GetEventFromLogs(logs, parser(common.HexToAddress("0x03")))
+	require.ErrorContains(t, err, "failed to find common.Address event in receipt logs")
+}
+
+// testBackend includes each sent transaction in its own block, with the given receipt status
+type testBackend struct {
+	status   uint64
+	sent     []*types.Transaction
+	estimate uint64
+}
+
+func (b *testBackend) NonceAt(context.Context, common.Address, *big.Int) (uint64, error) {
+	return uint64(len(b.sent)), nil
+}
+
+func (b *testBackend) EstimateGas(context.Context, interfaces.CallMsg) (uint64, error) {
+	return b.estimate, nil
+}
+
+func (b *testBackend) EstimateBaseFee(context.Context) (*big.Int, error) {
+	return big.NewInt(25), nil
+}
+
+func (b *testBackend) SuggestGasTipCap(context.Context) (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+
+func (b *testBackend) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	b.sent = append(b.sent, tx)
+	return nil
+}
+
+func (b *testBackend) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	for i, tx := range b.sent {
+		if tx.Hash() == txHash {
+			return &types.Receipt{Status: b.status, TxHash: txHash, BlockNumber: big.NewInt(int64(i + 1))}, nil
+		}
+	}
+	return nil, interfaces.NotFound
+}
+
+func (b *testBackend) BlockNumber(context.Context) (uint64, error) {
+	return uint64(len(b.sent)), nil
+}
+
+func TestSendTransactionAndWaitForSuccess(t *testing.T) {
+	ctx := context.Background()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainID := big.NewInt(43112)
+	to := common.HexToAddress("0x01")
+	backend := &testBackend{status: types.ReceiptStatusSuccessful, estimate: 50_000}
+
+	// The gas limit is estimated if it is not given.
+	tx, err := NewTransaction(ctx, backend, chainID, PrivateKeyToAddress(key), &to, 0, nil, []byte{1})
+	require.NoError(t, err)
+	require.Equal(t, uint64(50_000), tx.Gas())
+	require.Equal(t, big.NewInt(25*gasUtils.BaseFeeFactor+gasUtils.MaxPriorityFeePerGas), tx.GasFeeCap())
+	require.Equal(t, big.NewInt(1), tx.GasTipCap())
+	require.Equal(t, big.NewInt(0), tx.Value())
+
+	signedTx, err := SignTransaction(tx, key, chainID)
+	require.NoError(t, err)
+	receipt, err := SendTransactionAndWaitForSuccess(ctx, logging.NoLog{}, backend, signedTx)
+	require.NoError(t, err)
+	require.Equal(t, signedTx.Hash(), receipt.TxHash)
+
+	// Reverted transactions return the receipt along with the error.
+	backend.status = types.ReceiptStatusFailed
+	tx, err = NewTransaction(ctx, backend, chainID, PrivateKeyToAddress(key), &to, NativeTransferGas, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, NativeTransferGas, tx.Gas())
+	require.Equal(t, uint64(1), tx.Nonce())
+	signedTx, err = SignTransaction(tx, key, chainID)
+	require.NoError(t, err)
+	receipt, err = SendTransactionAndWaitForSuccess(ctx, logging.NoLog{}, backend, signedTx)
+	require.True(t, errors.Is(err, ErrTransactionFailed))
+	require.Equal(t, signedTx.Hash(), receipt.TxHash)
+}